@@ -1,6 +1,10 @@
 package ci
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/copyleftdev/specgrade/core"
+)
 
 // ExitHandler determines exit codes for CI/CD integration
 type ExitHandler struct {
@@ -49,3 +53,21 @@ func (e *ExitHandler) Handle(grade string) int {
 
 	return 1 // Failure
 }
+
+// HandleReport returns the exit code for a report. When the report was graded
+// against a baseline, any new finding fails the build even if the grade meets
+// the threshold; known findings never do. When graded against a git revision,
+// only findings introduced since the revision fail the build, so a change to a
+// spec that was already below the threshold can still pass.
+func (e *ExitHandler) HandleReport(report *core.Report) int {
+	if report.Since != nil {
+		if report.Since.NewFindings > 0 {
+			return 1
+		}
+		return 0
+	}
+	if report.Baseline != nil && report.Baseline.New > 0 {
+		return 1
+	}
+	return e.Handle(report.Grade)
+}
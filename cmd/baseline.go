@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/copyleftdev/specgrade/fetcher"
+	"github.com/copyleftdev/specgrade/runner"
+	"github.com/copyleftdev/specgrade/utils"
+	"github.com/spf13/cobra"
+)
+
+// DefaultBaselineFile is the file written by 'specgrade baseline create'
+const DefaultBaselineFile = "specgrade-baseline.json"
+
+var baselineOutput string
+
+var baselineCmd = &cobra.Command{
+	Use:   "baseline",
+	Short: "Manage the baseline of known findings",
+	Long: `A baseline records the current findings of a spec so that they can be fixed
+over time. When grading with --baseline, known findings are reported but only
+new findings affect the grade and the exit code.`,
+}
+
+var baselineCreateCmd = &cobra.Command{
+	Use:   "create [spec paths or patterns...]",
+	Short: "Record the current findings in a baseline file",
+	Long: `Grade the specs and write a fingerprint of every finding to a baseline file.
+Fingerprints combine the rule ID and the JSON path of the finding, so they stay
+stable when lines move.`,
+	Args: cobra.ArbitraryArgs,
+	RunE: createBaseline,
+}
+
+func init() {
+	rootCmd.AddCommand(baselineCmd)
+	baselineCmd.AddCommand(baselineCreateCmd)
+
+	baselineCreateCmd.Flags().StringVarP(&baselineOutput, "output", "o", DefaultBaselineFile, "Baseline file to write")
+	baselineCreateCmd.Flags().StringVar(&specVersion, "spec-version", "", "The official OpenAPI version to validate against (e.g., 3.1.0)")
+	baselineCreateCmd.Flags().StringVar(&specInput, "spec", "", "OpenAPI spec to record: a file path, - for stdin, or an http(s) URL")
+	baselineCreateCmd.Flags().StringVar(&targetDir, "target-dir", "", "Path to the local OpenAPI spec to record")
+	baselineCreateCmd.Flags().StringVar(&configPath, "config", "", "Optional path to specgrade.yaml config file")
+	baselineCreateCmd.Flags().StringVar(&skipRules, "skip", "", "Comma-separated rule IDs to ignore")
+}
+
+func createBaseline(cmd *cobra.Command, args []string) error {
+	// The baseline is not loaded, so every current finding is recorded
+	session, err := newGradingSession(args)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := session.context(cmd)
+	defer cancel()
+
+	baseline := &core.Baseline{
+		Version:   core.BaselineVersion,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		Findings:  []core.BaselineEntry{},
+	}
+
+	if len(session.specFiles) > 1 {
+		for _, specFile := range session.specFiles {
+			report, err := session.gradeSpec(ctx, fetcher.NewFileSpecLoader(specFile), nil)
+			if err != nil {
+				return fmt.Errorf("%s: %w", specFile, err)
+			}
+			baseline.Findings = append(baseline.Findings, runner.BaselineEntries(specIdentity(specFile), report.Rules)...)
+		}
+	} else {
+		target, specLoader := session.target()
+		report, err := session.gradeSpec(ctx, specLoader, nil)
+		if err != nil {
+			return err
+		}
+		baseline.Findings = runner.BaselineEntries(specIdentity(target), report.Rules)
+	}
+
+	if err := utils.SaveBaseline(baselineOutput, baseline); err != nil {
+		return err
+	}
+
+	fmt.Printf("📌 Baseline written to %s: %d findings\n", baselineOutput, len(baseline.Findings))
+	fmt.Printf("   Grade new changes with: specgrade --baseline %s\n", baselineOutput)
+	return nil
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/copyleftdev/specgrade/differ"
+	"github.com/copyleftdev/specgrade/fetcher"
+	"github.com/copyleftdev/specgrade/reporter"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffOutputFormat string
+	diffSpecVersion  string
+)
+
+// diffOutputFormats are the formats a diff can be written in. The other CI
+// formats of grading (sarif, junit, gitlab-codequality) describe rules, their
+// results and their fingerprints, which changes between two specs do not have.
+var diffOutputFormats = []string{"json", "cli", "developer", "markdown", "html", "github"}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <old spec> <new spec>",
+	Short: "Report breaking changes between two versions of a spec",
+	Long: `Compare two versions of an OpenAPI spec and classify every change as breaking
+or non-breaking: removed paths and operations, new required parameters,
+narrowed enums, changed response schemas, type changes and removed security
+schemes. Exits non-zero when there are breaking changes.
+
+Specs are file paths, http(s) URLs or - for stdin (for one of them).`,
+	Args: cobra.ExactArgs(2),
+	RunE: runDiff,
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().StringVar(&diffOutputFormat, "output-format", "cli", "Output format: json, cli, developer, html, markdown, or github (sarif, junit and gitlab-codequality are not supported)")
+	diffCmd.Flags().StringVar(&diffSpecVersion, "spec-version", "3.1.0", "The official OpenAPI version to compare against (e.g., 3.1.0)")
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	version, err := gradingVersion(diffSpecVersion)
+	if err != nil {
+		return err
+	}
+	format := strings.ToLower(diffOutputFormat)
+	if !slices.Contains(diffOutputFormats, format) {
+		return fmt.Errorf("unsupported output format for diff: %s (supported: %s; sarif, junit and gitlab-codequality are only available when grading)",
+			diffOutputFormat, strings.Join(diffOutputFormats, ", "))
+	}
+
+	oldSpec, err := fetcher.NewSpecLoader(args[0]).LoadContext(version)
+	if err != nil {
+		return fmt.Errorf("failed to load old spec %s: %w", args[0], err)
+	}
+	newSpec, err := fetcher.NewSpecLoader(args[1]).LoadContext(version)
+	if err != nil {
+		return fmt.Errorf("failed to load new spec %s: %w", args[1], err)
+	}
+
+	diff := differ.Compare(oldSpec, newSpec)
+	diff.Old, diff.New = args[0], args[1]
+
+	// Output report in requested format
+	rep := reporter.NewReporter()
+	var output string
+	switch format {
+	case "json":
+		output, err = rep.FormatDiffJSON(diff)
+		if err != nil {
+			return fmt.Errorf("failed to format JSON output: %w", err)
+		}
+	case "markdown":
+		output = rep.FormatDiffMarkdown(diff)
+	case "html":
+		output = rep.FormatDiffHTML(diff)
+	case "cli":
+		output = rep.FormatDiffCLI(diff)
+	case "developer":
+		output = rep.FormatDiffDeveloperCLI(diff)
+	case "github":
+		output = rep.FormatDiffGitHub(diff)
+	}
+
+	fmt.Print(output)
+
+	// Breaking changes fail the CI build
+	if diff.BreakingChanges > 0 {
+		os.Exit(1)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/copyleftdev/specgrade/history"
+	"github.com/copyleftdev/specgrade/reporter"
+	"github.com/copyleftdev/specgrade/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyOutputFormat string
+	historyListDir      string
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history [spec...]",
+	Short: "Show the score of a spec over time",
+	Long: `Print the grade and score of every run recorded with --history-dir (or
+history_dir in specgrade.yaml), oldest first. Without arguments, the history
+of every recorded spec is shown.`,
+	Args: cobra.ArbitraryArgs,
+	RunE: runHistory,
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+
+	historyCmd.Flags().StringVar(&historyListDir, "history-dir", "", "History directory (default: history_dir from the config file, or "+history.DefaultDir+")")
+	historyCmd.Flags().StringVar(&historyOutputFormat, "output-format", "cli", "Output format: json or cli")
+	historyCmd.Flags().StringVar(&configPath, "config", "", "Optional path to specgrade.yaml config file")
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	config, err := utils.LoadConfig(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	dir := historyListDir
+	if dir == "" {
+		dir = config.HistoryDir
+	}
+	if dir == "" {
+		dir = history.DefaultDir
+	}
+	store := history.NewStore(dir)
+
+	specs := make([]string, 0, len(args))
+	for _, arg := range args {
+		specs = append(specs, specIdentity(arg))
+	}
+	if len(specs) == 0 {
+		specs, err = store.Specs()
+		if err != nil {
+			return err
+		}
+	}
+
+	var histories []core.SpecHistory
+	for _, spec := range specs {
+		entries, err := store.Entries(spec)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return fmt.Errorf("no history recorded for %s in %s", spec, dir)
+		}
+		histories = append(histories, core.SpecHistory{Spec: spec, Entries: entries})
+	}
+	if len(histories) == 0 {
+		return fmt.Errorf("no history recorded in %s (grade with --history-dir to record runs)", dir)
+	}
+
+	var output string
+	rep := reporter.NewReporter()
+	switch strings.ToLower(historyOutputFormat) {
+	case "json":
+		output, err = rep.FormatHistoryJSON(histories)
+		if err != nil {
+			return fmt.Errorf("failed to format JSON output: %w", err)
+		}
+	case "cli":
+		output = rep.FormatHistoryCLI(histories)
+	default:
+		return fmt.Errorf("unsupported output format: %s (supported: json, cli)", historyOutputFormat)
+	}
+
+	fmt.Print(output)
+	return nil
+}
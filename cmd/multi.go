@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/copyleftdev/specgrade/ci"
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/copyleftdev/specgrade/fetcher"
+)
+
+// runMultiSpecGrade grades several specs in parallel and prints a combined
+// report. It exits non-zero if any spec is below the threshold or fails to load.
+func runMultiSpecGrade(ctx context.Context, session *gradingSession) error {
+	config, specFiles, rep := session.config, session.specFiles, session.reporter
+	exitHandler := ci.NewExitHandler(config.FailThreshold)
+
+	workers := concurrency
+	if workers < 1 {
+		workers = 1
+	}
+
+	// Reports are stored by index so the output order matches the sorted spec list
+	specReports := make([]core.SpecReport, len(specFiles))
+	semaphore := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+
+	for i, specFile := range specFiles {
+		wg.Add(1)
+		go func(i int, specFile string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			specReport := core.SpecReport{
+				Target:    specFile,
+				Threshold: config.FailThreshold,
+			}
+
+			report, err := session.gradeSpec(ctx, fetcher.NewFileSpecLoader(specFile), session.knownFindings(specFile))
+			if err == nil {
+				err = session.recordHistory(specFile, report)
+			}
+			if err != nil {
+				specReport.Error = err.Error()
+			} else {
+				specReport.Report = report
+				specReport.Passed = exitHandler.HandleReport(report) == 0
+			}
+
+			specReports[i] = specReport
+		}(i, specFile)
+	}
+	wg.Wait()
+
+	multi := rep.GenerateMultiReport(specReports)
+
+	// Output report in requested format
+	var output string
+	switch strings.ToLower(config.OutputFormat) {
+	case "json":
+		var err error
+		output, err = rep.FormatMultiJSON(multi)
+		if err != nil {
+			return fmt.Errorf("failed to format JSON output: %w", err)
+		}
+	case "markdown":
+		output = rep.FormatMultiMarkdown(multi)
+	case "html":
+		output = rep.FormatMultiHTML(multi)
+	case "cli":
+		output = rep.FormatMultiCLI(multi)
+	case "developer":
+		// Full developer output per spec, followed by the aggregate summary
+		var builder strings.Builder
+		for _, spec := range multi.Specs {
+			if spec.Report != nil {
+				builder.WriteString(rep.FormatDeveloperCLI(spec.Report, spec.Target))
+				builder.WriteString("\n")
+			}
+		}
+		builder.WriteString(rep.FormatMultiCLI(multi))
+		output = builder.String()
+	case "sarif":
+		var err error
+		output, err = rep.FormatMultiSARIF(multi, session.rules)
+		if err != nil {
+			return fmt.Errorf("failed to format SARIF output: %w", err)
+		}
+	case "junit":
+		var err error
+		output, err = rep.FormatMultiJUnit(multi)
+		if err != nil {
+			return fmt.Errorf("failed to format JUnit output: %w", err)
+		}
+	case "github":
+		output = rep.FormatMultiGitHub(multi)
+	case "gitlab-codequality":
+		var err error
+		output, err = rep.FormatMultiGitLabCodeQuality(multi)
+		if err != nil {
+			return fmt.Errorf("failed to format Code Quality output: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported output format: %s (supported: json, cli, developer, markdown, html, sarif, junit, github, gitlab-codequality)", config.OutputFormat)
+	}
+
+	fmt.Print(output)
+
+	// Exit with appropriate code for CI/CD
+	if multi.Aggregate.FailedSpecs > 0 {
+		os.Exit(1)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/copyleftdev/specgrade/schema"
+	"github.com/spf13/cobra"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Work with JSON reports",
+	Long: `JSON reports (--output-format json) follow a published JSON Schema, found in
+schema/report.schema.json. Its version is recorded in the schema_version field
+of every report.`,
+}
+
+var reportValidateCmd = &cobra.Command{
+	Use:   "validate <report.json>",
+	Short: "Validate a JSON report against the report schema",
+	Long: `Check that a JSON report matches the report schema, for example before
+feeding it to another tool. Every mismatch is listed with its JSON path and the
+command exits with status 1.`,
+	Args: cobra.ExactArgs(1),
+	RunE: validateReport,
+}
+
+var reportSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of the JSON report",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Print(string(schema.ReportSchema()))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.AddCommand(reportValidateCmd)
+	reportCmd.AddCommand(reportSchemaCmd)
+}
+
+func validateReport(cmd *cobra.Command, args []string) error {
+	path := args[0]
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read report: %w", err)
+	}
+
+	// A report written by another version of SpecGrade fails on most fields,
+	// so the version mismatch is reported on its own
+	var header struct {
+		SchemaVersion *string `json:"schema_version"`
+	}
+	if err := json.Unmarshal(data, &header); err == nil && header.SchemaVersion != nil && *header.SchemaVersion != core.ReportSchemaVersion {
+		fmt.Printf("❌ %s uses report schema %s, this version of SpecGrade validates schema %s\n", path, *header.SchemaVersion, core.ReportSchemaVersion)
+		os.Exit(1)
+	}
+
+	problems, err := schema.ValidateReport(data)
+	if err != nil {
+		return fmt.Errorf("failed to validate %s: %w", path, err)
+	}
+
+	if len(problems) == 0 {
+		fmt.Printf("✅ %s is a valid SpecGrade report (schema %s)\n", path, core.ReportSchemaVersion)
+		return nil
+	}
+
+	fmt.Printf("❌ %s does not match the report schema %s (%d problems):\n", path, core.ReportSchemaVersion, len(problems))
+	for _, problem := range problems {
+		fmt.Printf("  - %s\n", problem)
+	}
+	os.Exit(1)
+	return nil
+}
@@ -1,13 +1,18 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
+	"time"
 
 	"github.com/copyleftdev/specgrade/ci"
 	"github.com/copyleftdev/specgrade/core"
 	"github.com/copyleftdev/specgrade/fetcher"
+	"github.com/copyleftdev/specgrade/history"
 	"github.com/copyleftdev/specgrade/registry"
 	"github.com/copyleftdev/specgrade/reporter"
 	"github.com/copyleftdev/specgrade/rules"
@@ -19,31 +24,52 @@ import (
 
 var (
 	specVersion   string
+	specInput     string
 	targetDir     string
 	outputFormat  string
 	failThreshold string
 	configPath    string
 	skipRules     string
+	profileName   string
+	baselinePath  string
+	sinceRef      string
+	historyDir    string
 	generateDocs  bool
+	concurrency   int
+	ruleTimeout   time.Duration
+	timeout       time.Duration
 )
 
 var rootCmd = &cobra.Command{
-	Use:   "specgrade",
+	Use:   "specgrade [spec paths or patterns...]",
 	Short: "A modular, dynamic, and CICD-optimized conformance validator for OpenAPI specifications",
 	Long: `SpecGrade is a modular, dynamic, and CICD-optimized conformance validator for OpenAPI specifications. 
 It fetches versioned OpenAPI schema definitions, dynamically constructs validation rule sets based on that schema, 
-and grades the conformance of target API specs against those rules.`,
+and grades the conformance of target API specs against those rules.
+
+Specs can be passed as arguments: files, directories, globs (services/*/api) or
+recursive patterns (./services/...). When several specs match, each is graded
+and an aggregate grade is reported.`,
+	Args: cobra.ArbitraryArgs,
 	RunE: runSpecGrade,
 }
 
 func init() {
-	rootCmd.Flags().StringVar(&specVersion, "spec-version", "", "The official OpenAPI version to validate against (e.g., 3.1.0)")
+	rootCmd.Flags().StringVar(&specVersion, "spec-version", "", "The official OpenAPI version to validate against (e.g., 3.1.0); Swagger 2.0 specs are detected and converted automatically")
+	rootCmd.Flags().StringVar(&specInput, "spec", "", "OpenAPI spec to validate: a file path, - for stdin, or an http(s) URL")
 	rootCmd.Flags().StringVar(&targetDir, "target-dir", "", "Path to the local OpenAPI spec to validate")
-	rootCmd.Flags().StringVar(&outputFormat, "output-format", "", "Output format: json, cli, html, or markdown")
+	rootCmd.Flags().StringVar(&outputFormat, "output-format", "", "Output format: json, cli, developer, html, markdown, sarif, junit, github, or gitlab-codequality")
 	rootCmd.Flags().StringVar(&failThreshold, "fail-threshold", "", "Minimum acceptable grade (A, B, etc). Will exit non-zero if below")
 	rootCmd.Flags().StringVar(&configPath, "config", "", "Optional path to specgrade.yaml config file")
 	rootCmd.Flags().StringVar(&skipRules, "skip", "", "Comma-separated rule IDs to ignore")
+	rootCmd.Flags().StringVar(&profileName, "profile", "", "Grading profile: strict, default, lenient, or a profile defined in specgrade.yaml")
+	rootCmd.Flags().StringVar(&baselinePath, "baseline", "", "Baseline file of known findings (see 'specgrade baseline create'); only new findings affect the grade and exit code")
+	rootCmd.Flags().StringVar(&sinceRef, "since", "", "Git ref (e.g. origin/main) to compare with: only findings introduced since its merge base with HEAD are reported and fail the run")
+	rootCmd.Flags().StringVar(&historyDir, "history-dir", "", "Directory where each run is recorded; the report then includes the trend since the previous run (see 'specgrade history')")
 	rootCmd.Flags().BoolVar(&generateDocs, "docs", false, "Generate rule documentation (markdown)")
+	rootCmd.Flags().DurationVar(&ruleTimeout, "rule-timeout", runner.DefaultRuleTimeout, "Maximum time a single rule may run before it is reported as errored (0 disables)")
+	rootCmd.Flags().DurationVar(&timeout, "timeout", 0, "Maximum time for grading as a whole, e.g. 2m (0 disables)")
+	rootCmd.Flags().IntVar(&concurrency, "concurrency", runtime.NumCPU(), "Number of specs graded in parallel when several specs match")
 }
 
 func Execute() error {
@@ -51,18 +77,122 @@ func Execute() error {
 }
 
 func runSpecGrade(cmd *cobra.Command, args []string) error {
+	// Generate documentation if requested
+	if generateDocs {
+		return generateRuleDocumentation()
+	}
+
+	session, err := newGradingSession(args)
+	if err != nil {
+		return err
+	}
+	if err := session.loadBaseline(); err != nil {
+		return err
+	}
+
+	ctx, cancel := session.context(cmd)
+	defer cancel()
+
+	if sinceRef != "" && (len(session.specFiles) > 1 || session.baseline != nil) {
+		return fmt.Errorf("--since grades a single spec and cannot be combined with --baseline")
+	}
+
+	if len(session.specFiles) > 1 {
+		return runMultiSpecGrade(ctx, session)
+	}
+
+	target, specLoader := session.target()
+	var report *core.Report
+	if sinceRef != "" {
+		report, err = session.gradeSince(ctx, sinceRef, specLoader)
+	} else {
+		report, err = session.gradeSpec(ctx, specLoader, session.knownFindings(target))
+	}
+	if err != nil {
+		return err
+	}
+	if err := session.recordHistory(target, report); err != nil {
+		return err
+	}
+
+	// Output report in requested format
+	var output string
+	rep := session.reporter
+	switch strings.ToLower(session.config.OutputFormat) {
+	case "json":
+		output, err = rep.FormatJSON(report)
+		if err != nil {
+			return fmt.Errorf("failed to format JSON output: %w", err)
+		}
+	case "markdown":
+		output = rep.FormatMarkdown(report, target)
+	case "html":
+		output = rep.FormatHTML(report, target)
+	case "cli":
+		output = rep.FormatCLI(report, target)
+	case "developer":
+		output = rep.FormatDeveloperCLI(report, target)
+	case "sarif":
+		output, err = rep.FormatSARIF(report, target, session.rules)
+		if err != nil {
+			return fmt.Errorf("failed to format SARIF output: %w", err)
+		}
+	case "junit":
+		output, err = rep.FormatJUnit(report, target)
+		if err != nil {
+			return fmt.Errorf("failed to format JUnit output: %w", err)
+		}
+	case "github":
+		output = rep.FormatGitHub(report, target)
+	case "gitlab-codequality":
+		output, err = rep.FormatGitLabCodeQuality(report, target)
+		if err != nil {
+			return fmt.Errorf("failed to format Code Quality output: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported output format: %s (supported: json, cli, developer, markdown, html, sarif, junit, github, gitlab-codequality)", session.config.OutputFormat)
+	}
+
+	fmt.Print(output)
+
+	// Exit with appropriate code for CI/CD
+	exitCode := ci.NewExitHandler(session.config.FailThreshold).HandleReport(report)
+	if exitCode != 0 {
+		os.Exit(exitCode)
+	}
+
+	return nil
+}
+
+// gradingSession holds the configuration and components shared by the commands that grade specs
+type gradingSession struct {
+	config    *core.Config
+	specFiles []string // Specs matched by the positional arguments
+	runner    *runner.Runner
+	reporter  *reporter.Reporter
+	baseline  *core.Baseline // Known findings, nil without --baseline
+	rules     []core.Rule    // All registered rules, including skipped ones
+}
+
+// newGradingSession loads the configuration, merges the command line flags and
+// sets up the rule runner and the reporter
+func newGradingSession(args []string) (*gradingSession, error) {
 	// Load configuration
 	config, err := utils.LoadConfig(configPath)
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
 	// Create flags config for merging
 	flagsConfig := &core.Config{
 		SpecVersion:   specVersion,
+		Input:         specInput,
 		InputDir:      targetDir,
 		OutputFormat:  outputFormat,
 		FailThreshold: failThreshold,
+		Profile:       profileName,
+		Baseline:      baselinePath,
+		HistoryDir:    historyDir,
 	}
 
 	// Parse skip rules
@@ -76,9 +206,21 @@ func runSpecGrade(cmd *cobra.Command, args []string) error {
 	// Merge config with flags (flags take precedence)
 	finalConfig := utils.MergeConfigWithFlags(config, flagsConfig)
 
+	// Positional arguments select the specs to grade and take precedence over --spec/--target-dir
+	var specFiles []string
+	if len(args) > 0 {
+		specFiles, err = fetcher.DiscoverSpecs(args)
+		if err != nil {
+			return nil, err
+		}
+		if len(specFiles) == 1 {
+			finalConfig.Input = specFiles[0]
+		}
+	}
+
 	// Validate required fields
-	if finalConfig.InputDir == "" {
-		return fmt.Errorf("target directory is required (use --target-dir flag or input_dir in config file)")
+	if finalConfig.Input == "" && finalConfig.InputDir == "" && len(specFiles) == 0 {
+		return nil, fmt.Errorf("spec input is required (pass spec paths as arguments, use --spec or --target-dir flag, or input/input_dir in config file)")
 	}
 
 	// Set defaults if not specified
@@ -93,71 +235,172 @@ func runSpecGrade(cmd *cobra.Command, args []string) error {
 	}
 
 	// Validate spec version
-	if !versions.IsValidVersion(finalConfig.SpecVersion) {
-		return fmt.Errorf("unsupported OpenAPI version: %s", finalConfig.SpecVersion)
+	finalConfig.SpecVersion, err = gradingVersion(finalConfig.SpecVersion)
+	if err != nil {
+		return nil, err
 	}
 
-	// Generate documentation if requested
-	if generateDocs {
-		return generateRuleDocumentation()
+	// Resolve the grading profile (built-in or defined in the config file)
+	profile, err := reporter.ResolveProfile(finalConfig.Profile, finalConfig.Profiles)
+	if err != nil {
+		return nil, err
 	}
 
+	// Rules turned off in the rules section are skipped, severity overrides also change their weight
+	finalConfig.SkipRules = append(finalConfig.SkipRules, finalConfig.DisabledRules()...)
+	severities := finalConfig.SeverityOverrides()
+	profile = reporter.WithRuleSeverities(profile, severities)
+
 	// Initialize components
 	ruleRegistry := registry.NewRuleRegistry()
 	registerRules(ruleRegistry)
-
-	specLoader := fetcher.NewLocalSpecLoader(finalConfig.InputDir)
+	if err := ruleRegistry.Configure(finalConfig.Rules); err != nil {
+		return nil, err
+	}
 	ruleRunner := runner.NewRunner(ruleRegistry, finalConfig.SkipRules)
-	rep := reporter.NewReporter()
-	exitHandler := ci.NewExitHandler(finalConfig.FailThreshold)
+	ruleRunner.SetRuleTimeout(ruleTimeout)
+	ruleRunner.SetSeverityOverrides(severities)
+
+	return &gradingSession{
+		config:    finalConfig,
+		specFiles: specFiles,
+		runner:    ruleRunner,
+		rules:     ruleRegistry.AllRules(),
+		reporter:  reporter.NewReporterWithGrader(reporter.NewProfileGrader(profile)),
+	}, nil
+}
 
-	// Load the OpenAPI spec
-	spec, err := specLoader.Load(finalConfig.SpecVersion)
+// loadBaseline loads the baseline file configured with --baseline or in the
+// config file. Findings recorded in the baseline do not affect the grade.
+func (s *gradingSession) loadBaseline() error {
+	if s.config.Baseline == "" {
+		return nil
+	}
+	baseline, err := utils.LoadBaseline(s.config.Baseline)
 	if err != nil {
-		return fmt.Errorf("failed to load OpenAPI spec: %w", err)
+		return err
+	}
+	// Baselines record spec identities; older ones may hold the paths as given
+	for i := range baseline.Findings {
+		if baseline.Findings[i].Spec != "" {
+			baseline.Findings[i].Spec = specIdentity(baseline.Findings[i].Spec)
+		}
 	}
+	s.baseline = baseline
+	return nil
+}
+
+// context returns the context for grading. Rules still running when the
+// overall deadline passes are reported as errored.
+func (s *gradingSession) context(cmd *cobra.Command) (context.Context, context.CancelFunc) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
 
-	// Create spec context
-	specContext := &core.SpecContext{
-		Spec:    spec,
-		Version: finalConfig.SpecVersion,
+// target returns the name and loader of the single spec to grade. An explicit
+// spec input (file, stdin or URL) takes precedence over the target directory.
+func (s *gradingSession) target() (string, core.ContextLoader) {
+	target := s.config.InputDir
+	var specLoader core.ContextLoader = fetcher.NewLocalSpecLoader(s.config.InputDir)
+	if s.config.Input != "" {
+		target = s.config.Input
+		specLoader = fetcher.NewSpecLoader(s.config.Input)
+	}
+	if target == fetcher.StdinInput {
+		target = "stdin"
+	}
+	return target, specLoader
+}
+
+// knownFindings returns the baseline entries of a spec, see runner.KnownEntries
+func (s *gradingSession) knownFindings(target string) []core.BaselineEntry {
+	return runner.KnownEntries(s.baseline, specIdentity(target), len(s.specFiles) <= 1)
+}
+
+// gradeSpec loads a spec, runs the rules against it and builds its report.
+// With a baseline, the known findings are excluded before grading.
+func (s *gradingSession) gradeSpec(ctx context.Context, specLoader core.ContextLoader, known []core.BaselineEntry) (*core.Report, error) {
+	// Load the OpenAPI spec along with its source positions
+	specContext, err := specLoader.LoadContext(s.config.SpecVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI spec: %w", err)
 	}
 
 	// Run validation rules
-	results := ruleRunner.Run(specContext)
+	results := s.runner.RunContext(ctx, specContext)
+
+	var baselineSummary *core.BaselineSummary
+	if s.baseline != nil {
+		baselineSummary = runner.ApplyBaseline(results, known)
+		baselineSummary.File = s.config.Baseline
+	}
 
 	// Generate report
-	report := rep.GenerateReport(finalConfig.SpecVersion, results)
+	report := s.reporter.GenerateReport(specContext, results)
+	report.Baseline = baselineSummary
+	s.addMetadata(report, specContext)
 
-	// Output report in requested format
-	var output string
-	switch strings.ToLower(finalConfig.OutputFormat) {
-	case "json":
-		output, err = rep.FormatJSON(report)
-		if err != nil {
-			return fmt.Errorf("failed to format JSON output: %w", err)
-		}
-	case "markdown":
-		output = rep.FormatMarkdown(report, finalConfig.InputDir)
-	case "html":
-		output = rep.FormatHTML(report, finalConfig.InputDir)
-	case "cli":
-		output = rep.FormatCLI(report, finalConfig.InputDir)
-	case "developer":
-		output = rep.FormatDeveloperCLI(report, finalConfig.InputDir)
-	default:
-		return fmt.Errorf("unsupported output format: %s (supported: json, cli, developer, markdown, html)", finalConfig.OutputFormat)
+	return report, nil
+}
+
+// addMetadata records the spec version before conversion and the config in the report
+func (s *gradingSession) addMetadata(report *core.Report, specContext *core.SpecContext) {
+	if specContext.SourceVersion != "" {
+		report.Metadata["source_version"] = specContext.SourceVersion
 	}
+	if hash := utils.ConfigHash(s.config); hash != "" {
+		report.Metadata["config_hash"] = hash
+	}
+}
 
-	fmt.Print(output)
+// recordHistory saves the report to the history directory and fills in the
+// trend since the previous run of the same spec. Without a history directory
+// nothing is recorded.
+func (s *gradingSession) recordHistory(target string, report *core.Report) error {
+	if s.config.HistoryDir == "" {
+		return nil
+	}
+	trends, err := history.NewStore(s.config.HistoryDir).Record(specIdentity(target), report, time.Now())
+	if err != nil {
+		return err
+	}
+	if report.Analytics != nil {
+		report.Analytics.QualityTrends = trends
+	}
+	return nil
+}
 
-	// Exit with appropriate code for CI/CD
-	exitCode := exitHandler.Handle(report.Grade)
-	if exitCode != 0 {
-		os.Exit(exitCode)
+// gradingVersion validates a spec version and returns the version specs are
+// graded against. Swagger 2.0 is graded as the OpenAPI version it converts to.
+func gradingVersion(version string) (string, error) {
+	if !versions.IsValidVersion(version) {
+		return "", fmt.Errorf("unsupported OpenAPI version: %s", version)
 	}
+	return versions.GradingVersion(version), nil
+}
 
-	return nil
+// specIdentity identifies a spec across runs: URLs are used as is, paths are
+// made relative to the working directory so the history survives a moved checkout
+func specIdentity(target string) string {
+	if target == "stdin" || fetcher.IsURL(target) {
+		return target
+	}
+	abs, err := filepath.Abs(target)
+	if err != nil {
+		return filepath.ToSlash(target)
+	}
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, abs); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return filepath.ToSlash(abs)
 }
 
 // registerRules registers all available validation rules
@@ -173,6 +416,23 @@ func registerRules(registry *registry.RuleRegistry) {
 	registry.Register(&rules.OperationDescriptionRule{})
 	registry.Register(&rules.ErrorResponseRule{})
 	registry.Register(&rules.SecuritySchemeRule{})
+	registry.Register(&rules.CircularSchemaReferenceRule{})
+
+	// HTTP semantics rules
+	for _, rule := range rules.HTTPSemanticsRules() {
+		registry.Register(rule)
+	}
+
+	// Security completeness rules
+	for _, rule := range rules.SecurityRules() {
+		registry.Register(rule)
+	}
+	registry.Register(&rules.SensitiveDataRule{})
+
+	// Naming convention rules
+	for _, rule := range rules.NamingRules() {
+		registry.Register(rule)
+	}
 }
 
 // generateRuleDocumentation generates markdown documentation for all rules
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/copyleftdev/specgrade/fetcher"
+)
+
+// gradeSince grades a spec and compares it with its version at the merge base
+// of ref and HEAD. The report only counts the findings introduced since the
+// merge base; its since summary holds the grade of the whole spec.
+func (s *gradingSession) gradeSince(ctx context.Context, ref string, specLoader core.ContextLoader) (*core.Report, error) {
+	if s.config.Input == fetcher.StdinInput || fetcher.IsURL(s.config.Input) {
+		return nil, fmt.Errorf("--since needs a spec in a local git repository")
+	}
+	input := s.config.Input
+	if input == "" {
+		input = s.config.InputDir
+	}
+
+	// A spec that did not exist at the merge base has no findings to compare with
+	baseLoader := fetcher.NewGitSpecLoader(input, ref)
+	baseReport, err := s.gradeSpec(ctx, baseLoader, nil)
+	if err != nil && !errors.Is(err, fetcher.ErrSpecNotInRevision) {
+		return nil, err
+	}
+
+	specContext, err := specLoader.LoadContext(s.config.SpecVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI spec: %w", err)
+	}
+	results := s.runner.RunContext(ctx, specContext)
+
+	report := s.reporter.GenerateSinceReport(specContext, results, baseReport, &core.SinceSummary{
+		Ref:    ref,
+		Commit: baseLoader.Commit(),
+	})
+	s.addMetadata(report, specContext)
+	return report, nil
+}
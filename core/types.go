@@ -1,6 +1,14 @@
 package core
 
-import "github.com/getkin/kin-openapi/openapi3"
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
 
 // Rule represents a validation rule that can be applied to an OpenAPI spec
 type Rule interface {
@@ -21,6 +29,129 @@ type RuleResult struct {
 	Suggestion *ActionableFix    `json:"suggestion,omitempty"`
 	Metadata   map[string]string `json:"metadata,omitempty"`
 	Impact     *ImpactAnalysis   `json:"impact,omitempty"`
+	Findings   []Finding         `json:"findings,omitempty"`   // Individual violations behind a failed rule
+	Errored    bool              `json:"errored,omitempty"`    // The rule panicked or timed out instead of evaluating the spec
+	Suppressed []Suppression     `json:"suppressed,omitempty"` // Findings silenced with x-specgrade-ignore
+	Known      []Finding         `json:"known,omitempty"`      // Findings recorded in the baseline, excluded from grading
+}
+
+// Suppression records a finding silenced by an x-specgrade-ignore extension in the spec
+type Suppression struct {
+	RuleID   string        `json:"rule_id"`
+	Reason   string        `json:"reason"`
+	Detail   string        `json:"detail"`             // The suppressed finding
+	Location *RuleLocation `json:"location,omitempty"` // Where the suppressed finding is
+	Declared *RuleLocation `json:"declared,omitempty"` // Where the extension is declared
+}
+
+// BaselineVersion is the version of the baseline file format
+const BaselineVersion = 1
+
+// Baseline is a snapshot of known findings. Findings in the baseline are
+// reported as known and do not affect the grade or the exit code.
+type Baseline struct {
+	Version   int             `json:"version"`
+	CreatedAt string          `json:"created_at"`
+	Findings  []BaselineEntry `json:"findings"`
+}
+
+// BaselineEntry identifies a known finding by its fingerprint: the rule ID
+// and the JSON path of the finding, which stay stable when lines move
+type BaselineEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	Spec        string `json:"spec,omitempty"` // The spec the finding belongs to
+	RuleID      string `json:"rule_id"`
+	Path        string `json:"path,omitempty"`
+	Detail      string `json:"detail"`
+}
+
+// ForSpec returns the entries recorded for a spec
+func (b *Baseline) ForSpec(spec string) []BaselineEntry {
+	var entries []BaselineEntry
+	for _, entry := range b.Findings {
+		if entry.Spec == spec {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// SinceSummary compares a spec with its version at the merge base of a git ref
+// (--since). The rules, grade and summary of the report only count the findings
+// introduced since; Grade and Score are those of the whole spec.
+type SinceSummary struct {
+	Ref           string `json:"ref"`
+	Commit        string `json:"commit"` // Merge base of the ref and HEAD
+	Grade         string `json:"grade"`
+	Score         int    `json:"score"`
+	BaseGrade     string `json:"base_grade,omitempty"` // Empty when the spec did not exist at the merge base
+	BaseScore     int    `json:"base_score"`
+	ScoreDelta    int    `json:"score_delta"`    // Score minus BaseScore
+	NewFindings   int    `json:"new_findings"`   // Findings introduced since the merge base
+	FixedFindings int    `json:"fixed_findings"` // Findings resolved since the merge base
+}
+
+// HistoryEntry is the record of a single grading run of a spec, see --history-dir
+type HistoryEntry struct {
+	Spec      string    `json:"spec"`
+	Timestamp time.Time `json:"timestamp"`
+	Grade     string    `json:"grade"`
+	Score     int       `json:"score"`
+	Issues    int       `json:"issues"`
+	Findings  []string  `json:"findings"` // Fingerprints of all findings, including known ones
+}
+
+// SpecHistory is the recorded history of a spec, oldest run first
+type SpecHistory struct {
+	Spec    string         `json:"spec"`
+	Entries []HistoryEntry `json:"entries"`
+}
+
+// BaselineSummary reports how the findings of a spec compare to the baseline
+type BaselineSummary struct {
+	File  string `json:"file"`
+	Known int    `json:"known"` // Findings recorded in the baseline
+	New   int    `json:"new"`   // Findings not in the baseline
+	Fixed int    `json:"fixed"` // Baseline entries that no longer occur
+}
+
+// Finding represents a single violation reported by a rule at a specific location
+type Finding struct {
+	Detail   string            `json:"detail"`
+	Severity string            `json:"severity,omitempty"` // Defaults to the rule severity when empty
+	Category string            `json:"category,omitempty"` // Defaults to the rule category when empty
+	Location *RuleLocation     `json:"location,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Known    bool              `json:"known,omitempty"` // The finding is recorded in the baseline
+}
+
+// Issues returns the individual findings of a failed rule. Rules that only
+// report a rolled-up result yield a single finding built from the result itself.
+func (r RuleResult) Issues() []Finding {
+	if r.Passed {
+		return nil
+	}
+
+	if len(r.Findings) == 0 {
+		return []Finding{{
+			Detail:   r.Detail,
+			Severity: r.Severity,
+			Category: r.Category,
+			Location: r.Location,
+		}}
+	}
+
+	issues := make([]Finding, len(r.Findings))
+	for i, finding := range r.Findings {
+		if finding.Severity == "" {
+			finding.Severity = r.Severity
+		}
+		if finding.Category == "" {
+			finding.Category = r.Category
+		}
+		issues[i] = finding
+	}
+	return issues
 }
 
 // RuleLocation provides specific location information for issues
@@ -56,6 +187,48 @@ type ImpactAnalysis struct {
 type SpecContext struct {
 	Spec    *openapi3.T
 	Version string
+	Source  SourceLocator // Optional, resolves JSON paths to source positions
+
+	// SourceVersion is the version of the original document when it was converted
+	// before grading (e.g. "2.0" for Swagger), empty otherwise
+	SourceVersion string
+}
+
+// SourcePosition identifies where a node of the spec lives in its source files
+type SourcePosition struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+// SourceLocator resolves JSON paths such as "$.paths./users.get" to source positions
+type SourceLocator interface {
+	Locate(path string) (SourcePosition, bool)
+}
+
+// ContentHasher is implemented by sources that know the files a spec was loaded from
+type ContentHasher interface {
+	// ContentHash returns the hex sha256 of the bytes of those files
+	ContentHash() string
+}
+
+// Resolve fills in the file, line and column of a location from the spec source.
+// Locations without a JSON path, or that already carry a line number, are left untouched.
+func (c *SpecContext) Resolve(location *RuleLocation) *RuleLocation {
+	if c == nil || c.Source == nil || location == nil || location.Path == "" || location.Line > 0 {
+		return location
+	}
+
+	position, ok := c.Source.Locate(location.Path)
+	if !ok {
+		return location
+	}
+
+	location.File = position.File
+	location.Line = position.Line
+	location.Column = position.Column
+	location.FileRef = fmt.Sprintf("%s:%d:%d", position.File, position.Line, position.Column)
+	return location
 }
 
 // SpecLoader loads OpenAPI specifications
@@ -63,9 +236,70 @@ type SpecLoader interface {
 	Load(version string) (*openapi3.T, error)
 }
 
+// ContextLoader is a SpecLoader that can also provide the full evaluation
+// context, including source positions and conversion details
+type ContextLoader interface {
+	SpecLoader
+	LoadContext(version string) (*SpecContext, error)
+}
+
 // Grader assigns grades based on rule results
 type Grader interface {
 	Grade(results []RuleResult) string // Returns A, B, C, etc
+	CalculateScore(results []RuleResult) int
+	GradeForScore(score float64) string
+}
+
+// ScoreExplainer is implemented by graders that can explain how a score was calculated
+type ScoreExplainer interface {
+	Breakdown(results []RuleResult) *ScoreBreakdown
+}
+
+// ScoringModel configures how rule results are weighted into a score. Passed rules
+// earn their weight, failed rules cost weight × severity multiplier and a failed
+// critical rule caps the grade.
+type ScoringModel struct {
+	RuleWeights         map[string]float64 `yaml:"rule_weights" json:"rule_weights,omitempty"`       // Rule ID -> weight, defaults to 1
+	RuleSeverities      map[string]string  `yaml:"rule_severities" json:"rule_severities,omitempty"` // Rule ID -> severity used for weighting instead of the severity the rule reports
+	SeverityMultipliers map[string]float64 `yaml:"severity_multipliers" json:"severity_multipliers"` // Severity -> multiplier
+	CriticalRules       []string           `yaml:"critical_rules" json:"critical_rules,omitempty"`   // Rules whose failure caps the grade
+	CriticalCap         string             `yaml:"critical_cap" json:"critical_cap,omitempty"`       // Highest grade possible after a critical failure
+}
+
+// GradingProfile is a named grading curve. Profiles defined in specgrade.yaml
+// extend a built-in profile (default unless set) and only override what they set.
+type GradingProfile struct {
+	Name         string             `yaml:"-" json:"name"`
+	Description  string             `yaml:"description" json:"description,omitempty"`
+	Extends      string             `yaml:"extends" json:"extends,omitempty"`
+	Thresholds   map[string]float64 `yaml:"thresholds" json:"thresholds"`                 // Grade -> minimum score
+	CategoryCaps map[string]string  `yaml:"category_caps" json:"category_caps,omitempty"` // Rule category -> highest grade when a rule of it fails
+	Scoring      ScoringModel       `yaml:",inline" json:"scoring"`
+}
+
+// ScoreBreakdown explains how a report's score and grade were calculated
+type ScoreBreakdown struct {
+	Profile             string             `json:"profile,omitempty"`
+	Formula             string             `json:"formula"`
+	Thresholds          map[string]float64 `json:"thresholds,omitempty"`
+	SeverityMultipliers map[string]float64 `json:"severity_multipliers"`
+	EarnedPoints        float64            `json:"earned_points"`
+	PenaltyPoints       float64            `json:"penalty_points"`
+	RawScore            float64            `json:"raw_score"`
+	Cap                 string             `json:"cap,omitempty"`       // Grade cap applied because of critical or category failures
+	CappedBy            []string           `json:"capped_by,omitempty"` // Failed rules that caused the cap
+	Rules               []RuleScore        `json:"rules"`
+}
+
+// RuleScore is the contribution of a single rule to the score
+type RuleScore struct {
+	RuleID     string  `json:"rule_id"`
+	Weight     float64 `json:"weight"`
+	Severity   string  `json:"severity"`
+	Multiplier float64 `json:"multiplier"`
+	Earned     float64 `json:"earned"`  // Weight if the rule passed, 0 otherwise
+	Penalty    float64 `json:"penalty"` // Weight × multiplier if the rule failed, 0 otherwise
+	Critical   bool    `json:"critical,omitempty"`
 }
 
 // ExitHandler determines exit codes for CI/CD integration
@@ -76,22 +310,234 @@ type ExitHandler interface {
 // Config represents the configuration for SpecGrade
 type Config struct {
 	SpecVersion   string   `yaml:"spec_version"`
+	Input         string   `yaml:"input"` // Spec file path, "-" for stdin, or http(s) URL
 	InputDir      string   `yaml:"input_dir"`
 	FailThreshold string   `yaml:"fail_threshold"`
 	OutputFormat  string   `yaml:"output_format"`
 	SkipRules     []string `yaml:"skip_rules"`
+	Profile       string   `yaml:"profile"`     // Grading profile: strict, default, lenient or a custom profile
+	Baseline      string   `yaml:"baseline"`    // Baseline file of known findings
+	HistoryDir    string   `yaml:"history_dir"` // Directory where each run is recorded for trends
 	ConfigPath    string   `yaml:"-"`
+
+	Profiles map[string]GradingProfile `yaml:"profiles"` // Custom grading profiles
+	Rules    map[string]RuleSettings   `yaml:"rules"`    // Per-rule severity and options
+}
+
+// RuleSettingOff disables a rule in the rules section of the config
+const RuleSettingOff = "off"
+
+// RuleSettings configures a single rule. In YAML it is either a severity
+// ("off", "info", "warning" or "error") or a mapping with an optional
+// severity key; all other keys are passed to the rule as options.
+type RuleSettings struct {
+	Severity string
+	Options  RuleOptions
+}
+
+// UnmarshalYAML accepts both the short (severity only) and the long form
+func (s *RuleSettings) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		s.Severity = node.Value
+	case yaml.MappingNode:
+		var options map[string]interface{}
+		if err := node.Decode(&options); err != nil {
+			return err
+		}
+		if severity, ok := options["severity"]; ok {
+			s.Severity = fmt.Sprintf("%v", severity)
+			delete(options, "severity")
+		}
+		if len(options) > 0 {
+			s.Options = options
+		}
+	default:
+		return fmt.Errorf("line %d: rule settings must be a severity or a mapping", node.Line)
+	}
+
+	switch s.Severity {
+	case "", RuleSettingOff, "info", "warning", "error":
+		return nil
+	default:
+		return fmt.Errorf("line %d: unknown rule severity %q (expected off, info, warning or error)", node.Line, s.Severity)
+	}
+}
+
+// DisabledRules returns the IDs of rules turned off in the rules section
+func (c *Config) DisabledRules() []string {
+	var disabled []string
+	for ruleID, settings := range c.Rules {
+		if settings.Severity == RuleSettingOff {
+			disabled = append(disabled, ruleID)
+		}
+	}
+	sort.Strings(disabled)
+	return disabled
+}
+
+// SeverityOverrides returns the severities set for rules in the rules section
+func (c *Config) SeverityOverrides() map[string]string {
+	overrides := make(map[string]string)
+	for ruleID, settings := range c.Rules {
+		if settings.Severity != "" && settings.Severity != RuleSettingOff {
+			overrides[ruleID] = settings.Severity
+		}
+	}
+	return overrides
+}
+
+// ConfigurableRule is implemented by rules that accept options from the config
+type ConfigurableRule interface {
+	Rule
+	Configure(options RuleOptions) error
+}
+
+// RuleOptions are the rule-specific options from the rules section of the config
+type RuleOptions map[string]interface{}
+
+// Int returns an integer option, or fallback when it is not set
+func (o RuleOptions) Int(key string, fallback int) (int, error) {
+	value, ok := o[key]
+	if !ok {
+		return fallback, nil
+	}
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case float64:
+		if v == float64(int(v)) {
+			return int(v), nil
+		}
+	}
+	return 0, fmt.Errorf("option %s must be an integer, got %v", key, value)
+}
+
+// String returns a string option, or fallback when it is not set
+func (o RuleOptions) String(key, fallback string) (string, error) {
+	value, ok := o[key]
+	if !ok {
+		return fallback, nil
+	}
+	text, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("option %s must be a string, got %v", key, value)
+	}
+	return text, nil
+}
+
+// Bool returns a boolean option, or fallback when it is not set
+func (o RuleOptions) Bool(key string, fallback bool) (bool, error) {
+	value, ok := o[key]
+	if !ok {
+		return fallback, nil
+	}
+	flag, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("option %s must be true or false, got %v", key, value)
+	}
+	return flag, nil
 }
 
+// Strings returns a list of strings option, or nil when it is not set. A
+// single string is accepted as a list of one.
+func (o RuleOptions) Strings(key string) ([]string, error) {
+	value, ok := o[key]
+	if !ok {
+		return nil, nil
+	}
+	switch v := value.(type) {
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			text, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("option %s must be a list of strings, got %v", key, value)
+			}
+			values = append(values, text)
+		}
+		return values, nil
+	case []string:
+		return v, nil
+	}
+	return nil, fmt.Errorf("option %s must be a list of strings, got %v", key, value)
+}
+
+// CheckKeys returns an error for options that are not in the allowed list
+func (o RuleOptions) CheckKeys(allowed ...string) error {
+	known := make(map[string]bool, len(allowed))
+	for _, key := range allowed {
+		known[key] = true
+	}
+
+	var unknown []string
+	for key := range o {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown options: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// ReportSchemaVersion is the version of the JSON report schema (schema/report.schema.json).
+// It changes when fields are removed or change meaning, not when fields are added.
+const ReportSchemaVersion = "1.0"
+
 // Report represents the final validation report with enhanced developer insights
 type Report struct {
-	Version   string            `json:"version"`
-	Grade     string            `json:"grade"`
-	Score     int               `json:"score"`
-	Rules     []RuleResult      `json:"rules"`
-	Summary   *ReportSummary    `json:"summary"`
-	Analytics *ReportAnalytics  `json:"analytics"`
-	Metadata  map[string]string `json:"metadata,omitempty"`
+	SchemaVersion string            `json:"schema_version"`
+	Version       string            `json:"version"`
+	Grade         string            `json:"grade"`
+	Score         int               `json:"score"`
+	Rules         []RuleResult      `json:"rules"`
+	Summary       *ReportSummary    `json:"summary"`
+	Analytics     *ReportAnalytics  `json:"analytics"`
+	Scoring       *ScoreBreakdown   `json:"scoring,omitempty"`
+	Suppressions  []Suppression     `json:"suppressions,omitempty"` // All suppressed findings with their reasons
+	Baseline      *BaselineSummary  `json:"baseline,omitempty"`     // Set when graded against a baseline
+	Since         *SinceSummary     `json:"since,omitempty"`        // Set when graded against a git revision
+	Operations    []OperationRef    `json:"operations,omitempty"`   // Operations of the spec, in path order
+	Metadata      map[string]string `json:"metadata,omitempty"`
+}
+
+// OperationRef identifies an operation of the graded spec
+type OperationRef struct {
+	Path        string `json:"path"`
+	Method      string `json:"method"`
+	OperationID string `json:"operation_id,omitempty"`
+}
+
+// MultiReport combines the reports of several specs graded in one invocation
+type MultiReport struct {
+	SchemaVersion string          `json:"schema_version"` // ReportSchemaVersion, shared with single spec reports
+	Specs         []SpecReport    `json:"specs"`
+	Aggregate     *AggregateGrade `json:"aggregate"`
+}
+
+// SpecReport is the outcome of grading a single spec within a multi-spec run
+type SpecReport struct {
+	Target    string  `json:"target"`
+	Threshold string  `json:"threshold"`
+	Passed    bool    `json:"passed"` // Grade meets the threshold
+	Error     string  `json:"error,omitempty"`
+	Report    *Report `json:"report,omitempty"`
+}
+
+// AggregateGrade summarizes all specs of a multi-spec run
+type AggregateGrade struct {
+	Grade       string `json:"grade"`
+	Score       int    `json:"score"` // Average score of the successfully graded specs
+	TotalSpecs  int    `json:"total_specs"`
+	PassedSpecs int    `json:"passed_specs"`
+	FailedSpecs int    `json:"failed_specs"` // Below threshold or failed to load
+	ErrorSpecs  int    `json:"error_specs"`
+	TotalIssues int    `json:"total_issues"`
 }
 
 // ReportSummary provides high-level insights for developers
@@ -139,7 +585,7 @@ type ComplexityAnalysis struct {
 	ExternalRefs    int `json:"external_refs"`    // Number of external references
 }
 
-// QualityTrends tracks quality improvements over time (future enhancement)
+// QualityTrends compares a report with the previous recorded run of the same spec
 type QualityTrends struct {
 	PreviousScore  int    `json:"previous_score,omitempty"`
 	ScoreChange    int    `json:"score_change,omitempty"`    // +/- change from previous
@@ -164,3 +610,21 @@ type RiskAssessment struct {
 	ComplianceRisks  []string `json:"compliance_risks,omitempty"`
 	OverallRiskLevel string   `json:"overall_risk_level"` // low, medium, high, critical
 }
+
+// Change is a difference between two versions of a spec
+type Change struct {
+	Type        string        `json:"type"`     // e.g. operation-removed, type-changed
+	Breaking    bool          `json:"breaking"` // Existing clients may stop working
+	Description string        `json:"description"`
+	Location    *RuleLocation `json:"location,omitempty"` // In the new spec, or the old one for removals
+}
+
+// DiffReport lists the changes between two versions of a spec
+type DiffReport struct {
+	Old                string          `json:"old"`
+	New                string          `json:"new"`
+	BreakingChanges    int             `json:"breaking_changes"`
+	NonBreakingChanges int             `json:"non_breaking_changes"`
+	Changes            []Change        `json:"changes"`
+	RiskAssessment     *RiskAssessment `json:"risk_assessment"`
+}
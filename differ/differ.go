@@ -0,0 +1,392 @@
+package differ
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Change types reported by Compare
+const (
+	PathRemoved              = "path-removed"
+	PathAdded                = "path-added"
+	OperationRemoved         = "operation-removed"
+	OperationAdded           = "operation-added"
+	ParameterAdded           = "parameter-added"
+	ParameterRemoved         = "parameter-removed"
+	ParameterRequired        = "parameter-became-required"
+	ParameterOptional        = "parameter-became-optional"
+	RequestBodyRequired      = "request-body-became-required"
+	MediaTypeRemoved         = "media-type-removed"
+	MediaTypeAdded           = "media-type-added"
+	ResponseRemoved          = "response-removed"
+	ResponseAdded            = "response-added"
+	TypeChanged              = "type-changed"
+	EnumNarrowed             = "enum-narrowed"
+	EnumWidened              = "enum-widened"
+	PropertyRemoved          = "property-removed"
+	PropertyAdded            = "property-added"
+	PropertyRequired         = "property-became-required"
+	PropertyOptional         = "property-became-optional"
+	SecuritySchemeRemoved    = "security-scheme-removed"
+	SecuritySchemeAdded      = "security-scheme-added"
+	SecurityRequirementAdded = "security-requirement-added"
+)
+
+// differ collects the changes between two versions of a spec
+type differ struct {
+	old, new *core.SpecContext
+	changes  []core.Change
+}
+
+// Compare classifies the changes between two versions of a spec as breaking
+// (existing clients may stop working) or non-breaking. Locations point into the
+// new spec, except for removals, which point into the old one.
+func Compare(oldSpec, newSpec *core.SpecContext) *core.DiffReport {
+	d := &differ{old: oldSpec, new: newSpec}
+	d.comparePaths()
+	d.compareSecuritySchemes()
+
+	report := &core.DiffReport{
+		Changes: d.changes,
+		RiskAssessment: &core.RiskAssessment{
+			BreakingChanges:  []string{},
+			OverallRiskLevel: "low",
+		},
+	}
+	if report.Changes == nil {
+		report.Changes = []core.Change{}
+	}
+	for _, change := range report.Changes {
+		if change.Breaking {
+			report.BreakingChanges++
+			report.RiskAssessment.BreakingChanges = append(report.RiskAssessment.BreakingChanges, change.Description)
+		} else {
+			report.NonBreakingChanges++
+		}
+	}
+	if report.BreakingChanges > 0 {
+		report.RiskAssessment.OverallRiskLevel = "high"
+	}
+	return report
+}
+
+// breaking records a change that can break existing clients
+func (d *differ) breaking(changeType, path string, inOld bool, format string, args ...interface{}) {
+	d.add(changeType, true, path, inOld, format, args...)
+}
+
+// compatible records a change that existing clients keep working with
+func (d *differ) compatible(changeType, path string, inOld bool, format string, args ...interface{}) {
+	d.add(changeType, false, path, inOld, format, args...)
+}
+
+func (d *differ) add(changeType string, breaking bool, path string, inOld bool, format string, args ...interface{}) {
+	spec := d.new
+	if inOld {
+		spec = d.old
+	}
+	d.changes = append(d.changes, core.Change{
+		Type:        changeType,
+		Breaking:    breaking,
+		Description: fmt.Sprintf(format, args...),
+		Location:    spec.Resolve(&core.RuleLocation{Path: path}),
+	})
+}
+
+// comparePaths compares the paths and their operations. Paths are matched by
+// their template, so renaming a path parameter does not remove the path.
+func (d *differ) comparePaths() {
+	oldPaths, newPaths := d.old.Spec.Paths, d.new.Spec.Paths
+
+	oldTemplates := make(map[string]string, len(oldPaths))
+	for _, path := range sortedKeys(oldPaths) {
+		oldTemplates[pathTemplate(path)] = path
+	}
+	newTemplates := make(map[string]string, len(newPaths))
+	for _, path := range sortedKeys(newPaths) {
+		newTemplates[pathTemplate(path)] = path
+	}
+
+	for _, path := range sortedKeys(oldPaths) {
+		if _, ok := newTemplates[pathTemplate(path)]; !ok {
+			d.breaking(PathRemoved, "$.paths."+path, true, "Path %s was removed", path)
+		}
+	}
+	for _, path := range sortedKeys(newPaths) {
+		oldPath, ok := oldTemplates[pathTemplate(path)]
+		if !ok {
+			d.compatible(PathAdded, "$.paths."+path, false, "Path %s was added", path)
+			continue
+		}
+		d.compareOperations(oldPath, path, oldPaths[oldPath], newPaths[path])
+	}
+}
+
+// compareOperations compares the operations of a path present in both specs
+func (d *differ) compareOperations(oldPath, path string, oldItem, newItem *openapi3.PathItem) {
+	oldOps, newOps := oldItem.Operations(), newItem.Operations()
+
+	for _, method := range sortedKeys(oldOps) {
+		if _, ok := newOps[method]; !ok {
+			d.breaking(OperationRemoved, operationPath(oldPath, method), true, "%s %s was removed", method, path)
+		}
+	}
+	for _, method := range sortedKeys(newOps) {
+		oldOp, ok := oldOps[method]
+		if !ok {
+			d.compatible(OperationAdded, operationPath(path, method), false, "%s %s was added", method, path)
+			continue
+		}
+
+		op := operation{
+			name: method + " " + path,
+			path: operationPath(path, method),
+		}
+		oldParams := renamePathParameters(parameters(oldItem, oldOp), oldPath, path)
+		d.compareParameters(op, oldParams, parameters(newItem, newOps[method]))
+		d.compareRequestBody(op, oldOp.RequestBody, newOps[method].RequestBody)
+		d.compareResponses(op, oldOp.Responses, newOps[method].Responses)
+		d.compareSecurity(op, d.old.Spec.Security, oldOp.Security, d.new.Spec.Security, newOps[method].Security)
+	}
+}
+
+// operation identifies the operation a change belongs to
+type operation struct {
+	name string // e.g. "GET /users"
+	path string // JSON path of the operation
+}
+
+// compareParameters compares the parameters of an operation, matched by location and name
+func (d *differ) compareParameters(op operation, oldParams, newParams map[string]*openapi3.Parameter) {
+	for _, key := range sortedKeys(oldParams) {
+		if _, ok := newParams[key]; !ok {
+			d.compatible(ParameterRemoved, op.path+".parameters", true, "%s: %s parameter %s was removed", op.name, oldParams[key].In, oldParams[key].Name)
+		}
+	}
+
+	for _, key := range sortedKeys(newParams) {
+		param := newParams[key]
+		oldParam, ok := oldParams[key]
+		if !ok {
+			if param.Required {
+				d.breaking(ParameterAdded, op.path+".parameters", false, "%s: new required %s parameter %s", op.name, param.In, param.Name)
+			} else {
+				d.compatible(ParameterAdded, op.path+".parameters", false, "%s: new optional %s parameter %s", op.name, param.In, param.Name)
+			}
+			continue
+		}
+
+		if param.Required && !oldParam.Required {
+			d.breaking(ParameterRequired, op.path+".parameters", false, "%s: %s parameter %s is now required", op.name, param.In, param.Name)
+		} else if !param.Required && oldParam.Required {
+			d.compatible(ParameterOptional, op.path+".parameters", false, "%s: %s parameter %s is now optional", op.name, param.In, param.Name)
+		}
+
+		s := schemaComparison{
+			differ:  d,
+			request: true,
+			subject: fmt.Sprintf("%s: %s parameter %s", op.name, param.In, param.Name),
+			visited: make(map[[2]*openapi3.Schema]bool),
+		}
+		s.compare(op.path+".parameters", "", oldParam.Schema, param.Schema)
+	}
+}
+
+// compareRequestBody compares the request bodies of an operation
+func (d *differ) compareRequestBody(op operation, oldBody, newBody *openapi3.RequestBodyRef) {
+	if newBody == nil || newBody.Value == nil {
+		return
+	}
+	path := op.path + ".requestBody"
+
+	if oldBody == nil || oldBody.Value == nil {
+		if newBody.Value.Required {
+			d.breaking(RequestBodyRequired, path, false, "%s: a required request body was added", op.name)
+		}
+		return
+	}
+
+	if newBody.Value.Required && !oldBody.Value.Required {
+		d.breaking(RequestBodyRequired, path, false, "%s: the request body is now required", op.name)
+	}
+
+	d.compareContent(op, path, oldBody.Value.Content, newBody.Value.Content, true, "request body")
+}
+
+// compareResponses compares the responses of an operation by status code
+func (d *differ) compareResponses(op operation, oldResponses, newResponses openapi3.Responses) {
+	for _, status := range sortedKeys(oldResponses) {
+		if _, ok := newResponses[status]; ok {
+			continue
+		}
+		path := op.path + ".responses." + status
+		// Clients rely on the success responses, dropping a documented error is harmless
+		if strings.HasPrefix(status, "2") {
+			d.breaking(ResponseRemoved, path, true, "%s: response %s was removed", op.name, status)
+		} else {
+			d.compatible(ResponseRemoved, path, true, "%s: response %s was removed", op.name, status)
+		}
+	}
+
+	for _, status := range sortedKeys(newResponses) {
+		path := op.path + ".responses." + status
+		oldResponse, ok := oldResponses[status]
+		if !ok {
+			d.compatible(ResponseAdded, path, false, "%s: response %s was added", op.name, status)
+			continue
+		}
+		if oldResponse.Value == nil || newResponses[status].Value == nil {
+			continue
+		}
+		d.compareContent(op, path, oldResponse.Value.Content, newResponses[status].Value.Content, false, "response "+status)
+	}
+}
+
+// compareContent compares the media types of a request body or a response
+func (d *differ) compareContent(op operation, path string, oldContent, newContent openapi3.Content, request bool, subject string) {
+	for _, mediaType := range sortedKeys(oldContent) {
+		if _, ok := newContent[mediaType]; !ok {
+			d.breaking(MediaTypeRemoved, path+".content", true, "%s: %s no longer supports %s", op.name, subject, mediaType)
+		}
+	}
+
+	for _, mediaType := range sortedKeys(newContent) {
+		oldMedia, ok := oldContent[mediaType]
+		if !ok {
+			d.compatible(MediaTypeAdded, path+".content", false, "%s: %s now supports %s", op.name, subject, mediaType)
+			continue
+		}
+		if oldMedia == nil || newContent[mediaType] == nil {
+			continue
+		}
+
+		s := schemaComparison{
+			differ:  d,
+			request: request,
+			subject: fmt.Sprintf("%s: %s (%s)", op.name, subject, mediaType),
+			visited: make(map[[2]*openapi3.Schema]bool),
+		}
+		s.compare(path+".content."+mediaType+".schema", "", oldMedia.Schema, newContent[mediaType].Schema)
+	}
+}
+
+// compareSecurity reports security requirements added to an operation
+func (d *differ) compareSecurity(op operation, oldGlobal openapi3.SecurityRequirements, oldSecurity *openapi3.SecurityRequirements, newGlobal openapi3.SecurityRequirements, newSecurity *openapi3.SecurityRequirements) {
+	oldSchemes := securitySchemes(oldGlobal, oldSecurity)
+	if len(oldSchemes) > 0 {
+		return // Clients already authenticate, changed alternatives are covered by removed schemes
+	}
+	if schemes := securitySchemes(newGlobal, newSecurity); len(schemes) > 0 {
+		d.breaking(SecurityRequirementAdded, op.path, false, "%s now requires authentication (%s)", op.name, strings.Join(schemes, ", "))
+	}
+}
+
+// compareSecuritySchemes compares the security schemes of the components
+func (d *differ) compareSecuritySchemes() {
+	var oldSchemes, newSchemes openapi3.SecuritySchemes
+	if d.old.Spec.Components != nil {
+		oldSchemes = d.old.Spec.Components.SecuritySchemes
+	}
+	if d.new.Spec.Components != nil {
+		newSchemes = d.new.Spec.Components.SecuritySchemes
+	}
+
+	for _, name := range sortedKeys(oldSchemes) {
+		if _, ok := newSchemes[name]; !ok {
+			d.breaking(SecuritySchemeRemoved, "$.components.securitySchemes."+name, true, "Security scheme %s was removed", name)
+		}
+	}
+	for _, name := range sortedKeys(newSchemes) {
+		if _, ok := oldSchemes[name]; !ok {
+			d.compatible(SecuritySchemeAdded, "$.components.securitySchemes."+name, false, "Security scheme %s was added", name)
+		}
+	}
+}
+
+// parameters returns the parameters of an operation keyed by location and
+// name, including those inherited from the path item
+func parameters(item *openapi3.PathItem, op *openapi3.Operation) map[string]*openapi3.Parameter {
+	params := make(map[string]*openapi3.Parameter)
+	for _, list := range []openapi3.Parameters{item.Parameters, op.Parameters} {
+		for _, ref := range list {
+			if ref != nil && ref.Value != nil {
+				params[ref.Value.In+":"+ref.Value.Name] = ref.Value
+			}
+		}
+	}
+	return params
+}
+
+// pathParameter matches a parameter in a path template, e.g. {userId}
+var pathParameter = regexp.MustCompile(`\{[^}]*\}`)
+
+// pathTemplate returns a path with its parameter names left out, e.g.
+// /users/{} for /users/{id}. Paths with the same template are the same path.
+func pathTemplate(path string) string {
+	return pathParameter.ReplaceAllString(path, "{}")
+}
+
+// renamePathParameters keys the path parameters of the old path by the names
+// they have at the same position in the new path
+func renamePathParameters(params map[string]*openapi3.Parameter, oldPath, newPath string) map[string]*openapi3.Parameter {
+	oldNames, newNames := pathParameter.FindAllString(oldPath, -1), pathParameter.FindAllString(newPath, -1)
+
+	names := make(map[string]string, len(oldNames))
+	for i := range oldNames {
+		names[strings.Trim(oldNames[i], "{}")] = strings.Trim(newNames[i], "{}")
+	}
+
+	renamed := make(map[string]*openapi3.Parameter, len(params))
+	for key, param := range params {
+		if name, ok := names[param.Name]; ok && param.In == openapi3.ParameterInPath {
+			key = param.In + ":" + name
+		}
+		renamed[key] = param
+	}
+	return renamed
+}
+
+// securitySchemes lists the schemes an operation may authenticate with; an
+// empty list means the operation is public
+func securitySchemes(global openapi3.SecurityRequirements, security *openapi3.SecurityRequirements) []string {
+	requirements := global
+	if security != nil {
+		requirements = *security
+	}
+
+	seen := make(map[string]bool)
+	var schemes []string
+	for _, requirement := range requirements {
+		if len(requirement) == 0 {
+			return nil // An empty requirement makes authentication optional
+		}
+		for name := range requirement {
+			if !seen[name] {
+				seen[name] = true
+				schemes = append(schemes, name)
+			}
+		}
+	}
+	sort.Strings(schemes)
+	return schemes
+}
+
+// operationPath returns the JSON path of an operation
+func operationPath(path, method string) string {
+	return fmt.Sprintf("$.paths.%s.%s", path, strings.ToLower(method))
+}
+
+// sortedKeys returns the keys of a map in sorted order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
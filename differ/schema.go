@@ -0,0 +1,197 @@
+package differ
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// schemaComparison compares the schemas of a request or a response. Whether a
+// change breaks clients depends on the direction: clients send requests and
+// read responses, so a removed property is harmless in a request but breaking
+// in a response, while a new required property is the opposite.
+type schemaComparison struct {
+	differ  *differ
+	request bool
+	subject string                       // What the schema describes, e.g. "GET /users: response 200"
+	visited map[[2]*openapi3.Schema]bool // Schema pairs already compared, guards against circular refs
+}
+
+// compare compares two schemas and their properties, items and subschemas recursively.
+// field is the dotted name of the schema within the compared root schema.
+func (s *schemaComparison) compare(path, field string, oldRef, newRef *openapi3.SchemaRef) {
+	if oldRef == nil || newRef == nil || oldRef.Value == nil || newRef.Value == nil {
+		return
+	}
+	oldSchema, newSchema := oldRef.Value, newRef.Value
+
+	pair := [2]*openapi3.Schema{oldSchema, newSchema}
+	if s.visited[pair] {
+		return
+	}
+	s.visited[pair] = true
+
+	if oldSchema.Type != newSchema.Type && oldSchema.Type != "" && newSchema.Type != "" {
+		s.differ.breaking(TypeChanged, path, false, "%s: type changed from %s to %s", s.describe(field), oldSchema.Type, newSchema.Type)
+		return // Nested changes are meaningless once the type differs
+	}
+
+	s.compareEnum(path, field, oldSchema.Enum, newSchema.Enum)
+	s.compareProperties(path, field, oldSchema, newSchema)
+
+	if oldSchema.Items != nil && newSchema.Items != nil {
+		s.compare(path+".items", join(field, "[]"), oldSchema.Items, newSchema.Items)
+	}
+
+	s.compareComposition(path+".allOf", field, oldSchema.AllOf, newSchema.AllOf)
+	s.compareComposition(path+".oneOf", field, oldSchema.OneOf, newSchema.OneOf)
+	s.compareComposition(path+".anyOf", field, oldSchema.AnyOf, newSchema.AnyOf)
+}
+
+// compareComposition compares the subschemas of allOf, oneOf or anyOf, matched
+// by position. They describe the same field, so its name carries over.
+func (s *schemaComparison) compareComposition(path, field string, oldSchemas, newSchemas openapi3.SchemaRefs) {
+	for i := 0; i < len(oldSchemas) && i < len(newSchemas); i++ {
+		s.compare(fmt.Sprintf("%s.%d", path, i), field, oldSchemas[i], newSchemas[i])
+	}
+}
+
+// compareEnum reports enum values that were removed or added. Clients may
+// still send a removed value, and may not understand an added one.
+func (s *schemaComparison) compareEnum(path, field string, oldEnum, newEnum []interface{}) {
+	if len(newEnum) == 0 {
+		return // No enum accepts and returns any value
+	}
+
+	// A schema that had no enum before accepts any value, so a new enum narrows it
+	if len(oldEnum) == 0 {
+		values := joinValues(missingValues(newEnum, nil))
+		if s.request {
+			s.differ.breaking(EnumNarrowed, path, false, "%s: now only accepts %s", s.describe(field), values)
+		} else {
+			s.differ.compatible(EnumNarrowed, path, false, "%s: now only returns %s", s.describe(field), values)
+		}
+		return
+	}
+
+	removed := missingValues(oldEnum, newEnum)
+	added := missingValues(newEnum, oldEnum)
+
+	if len(removed) > 0 {
+		if s.request {
+			s.differ.breaking(EnumNarrowed, path, false, "%s: enum no longer accepts %s", s.describe(field), joinValues(removed))
+		} else {
+			s.differ.compatible(EnumNarrowed, path, false, "%s: enum no longer returns %s", s.describe(field), joinValues(removed))
+		}
+	}
+	if len(added) > 0 {
+		if s.request {
+			s.differ.compatible(EnumWidened, path, false, "%s: enum now accepts %s", s.describe(field), joinValues(added))
+		} else {
+			s.differ.breaking(EnumWidened, path, false, "%s: enum may now return %s", s.describe(field), joinValues(added))
+		}
+	}
+}
+
+// compareProperties compares the properties of two object schemas
+func (s *schemaComparison) compareProperties(path, field string, oldSchema, newSchema *openapi3.Schema) {
+	oldRequired, newRequired := stringSet(oldSchema.Required), stringSet(newSchema.Required)
+
+	for _, name := range sortedKeys(oldSchema.Properties) {
+		if _, ok := newSchema.Properties[name]; ok {
+			continue
+		}
+		propertyPath := path + ".properties." + name
+		if s.request {
+			s.differ.compatible(PropertyRemoved, propertyPath, true, "%s: property %s was removed", s.describe(field), name)
+		} else {
+			s.differ.breaking(PropertyRemoved, propertyPath, true, "%s: property %s was removed", s.describe(field), name)
+		}
+	}
+
+	for _, name := range sortedKeys(newSchema.Properties) {
+		propertyPath := path + ".properties." + name
+		oldProperty, existed := oldSchema.Properties[name]
+
+		switch {
+		case !existed && s.request && newRequired[name]:
+			s.differ.breaking(PropertyAdded, propertyPath, false, "%s: new required property %s", s.describe(field), name)
+		case !existed:
+			s.differ.compatible(PropertyAdded, propertyPath, false, "%s: new property %s", s.describe(field), name)
+		case newRequired[name] && !oldRequired[name]:
+			// Clients must now send the property, responses now always include it
+			if s.request {
+				s.differ.breaking(PropertyRequired, propertyPath, false, "%s: property %s is now required", s.describe(field), name)
+			} else {
+				s.differ.compatible(PropertyRequired, propertyPath, false, "%s: property %s is now always returned", s.describe(field), name)
+			}
+		case !newRequired[name] && oldRequired[name]:
+			// Clients may omit the property, responses may now leave it out
+			if s.request {
+				s.differ.compatible(PropertyOptional, propertyPath, false, "%s: property %s is now optional", s.describe(field), name)
+			} else {
+				s.differ.breaking(PropertyOptional, propertyPath, false, "%s: property %s is no longer always returned", s.describe(field), name)
+			}
+		}
+
+		if existed {
+			s.compare(propertyPath, join(field, name), oldProperty, newSchema.Properties[name])
+		}
+	}
+}
+
+// describe names a field of the compared schema for change descriptions
+func (s *schemaComparison) describe(field string) string {
+	if field == "" {
+		return s.subject
+	}
+	return fmt.Sprintf("%s field %s", s.subject, field)
+}
+
+// join appends a property name, or [] for array items, to a dotted field name
+func join(field, name string) string {
+	switch {
+	case field == "" && name == "[]":
+		return "items"
+	case field == "" || name == "[]":
+		return field + name
+	}
+	return field + "." + name
+}
+
+// missingValues returns the values of from that are not in to
+func missingValues(from, to []interface{}) []string {
+	present := make(map[string]bool, len(to))
+	for _, value := range to {
+		present[fmt.Sprint(value)] = true
+	}
+
+	var missing []string
+	for _, value := range from {
+		if !present[fmt.Sprint(value)] {
+			missing = append(missing, fmt.Sprint(value))
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// joinValues formats enum values for a change description
+func joinValues(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = fmt.Sprintf("%q", value)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// stringSet converts a list of strings into a set
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
@@ -0,0 +1,120 @@
+package fetcher
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SpecFileNames are the well-known spec file names, in order of preference
+var SpecFileNames = []string{
+	"openapi.yaml", "openapi.yml", "openapi.json",
+	"swagger.yaml", "swagger.yml", "swagger.json",
+	"api.yaml", "api.yml", "api.json",
+}
+
+// skippedDirs are never descended into during recursive discovery
+var skippedDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	"vendor":       true,
+}
+
+// DiscoverSpecs expands patterns into a sorted, de-duplicated list of spec files.
+// A pattern ending in "/..." (e.g. "./services/...") is searched recursively,
+// glob patterns (e.g. "services/*/api") are expanded, directories contribute
+// their well-known spec file and plain files are used as-is.
+func DiscoverSpecs(patterns []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var specs []string
+
+	add := func(path string) {
+		clean := filepath.Clean(path)
+		if !seen[clean] {
+			seen[clean] = true
+			specs = append(specs, clean)
+		}
+	}
+
+	for _, pattern := range patterns {
+		if root, recursive := strings.CutSuffix(filepath.ToSlash(pattern), "/..."); recursive {
+			if root == "" {
+				root = "."
+			}
+			found, err := discoverRecursive(root)
+			if err != nil {
+				return nil, err
+			}
+			for _, spec := range found {
+				add(spec)
+			}
+			continue
+		}
+
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid spec pattern %s: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no specs match: %s", pattern)
+		}
+
+		for _, match := range matches {
+			if !isDir(match) {
+				add(match)
+				continue
+			}
+			if spec, ok := specFileInDir(match); ok {
+				add(spec)
+			}
+		}
+	}
+
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("no OpenAPI spec files found in: %s", strings.Join(patterns, ", "))
+	}
+
+	sort.Strings(specs)
+	return specs, nil
+}
+
+// discoverRecursive walks root and returns the well-known spec file of every directory
+func discoverRecursive(root string) ([]string, error) {
+	var specs []string
+
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if path != root && skippedDirs[entry.Name()] {
+			return filepath.SkipDir
+		}
+
+		if spec, ok := specFileInDir(path); ok {
+			specs = append(specs, spec)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search %s for specs: %w", root, err)
+	}
+
+	return specs, nil
+}
+
+// specFileInDir returns the preferred well-known spec file in a directory
+func specFileInDir(dir string) (string, bool) {
+	for _, name := range SpecFileNames {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+	}
+	return "", false
+}
@@ -3,8 +3,10 @@ package fetcher
 import (
 	"fmt"
 	"io/ioutil"
+	"net/url"
 	"path/filepath"
 
+	"github.com/copyleftdev/specgrade/core"
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
@@ -22,41 +24,90 @@ func NewLocalSpecLoader(targetDir string) *LocalSpecLoader {
 
 // Load loads an OpenAPI spec from the target directory
 func (l *LocalSpecLoader) Load(version string) (*openapi3.T, error) {
-	// Look for common OpenAPI spec file names
-	possibleFiles := []string{
-		"openapi.yaml", "openapi.yml", "openapi.json",
-		"swagger.yaml", "swagger.yml", "swagger.json",
-		"api.yaml", "api.yml", "api.json",
+	specFile, err := l.findSpecFile()
+	if err != nil {
+		return nil, err
 	}
+	return NewFileSpecLoader(specFile).Load(version)
+}
 
-	var specFile string
-	for _, file := range possibleFiles {
+// LoadContext loads the spec together with a source index, so rule findings
+// can be resolved to the file, line and column they originate from
+func (l *LocalSpecLoader) LoadContext(version string) (*core.SpecContext, error) {
+	specFile, err := l.findSpecFile()
+	if err != nil {
+		return nil, err
+	}
+	return NewFileSpecLoader(specFile).LoadContext(version)
+}
+
+// findSpecFile looks for a spec with a well-known name in the target directory
+func (l *LocalSpecLoader) findSpecFile() (string, error) {
+	// Look for common OpenAPI spec file names
+	for _, file := range SpecFileNames {
 		fullPath := filepath.Join(l.targetDir, file)
 		if fileExists(fullPath) {
-			specFile = fullPath
-			break
+			return fullPath, nil
 		}
 	}
 
-	if specFile == "" {
-		return nil, fmt.Errorf("no OpenAPI spec file found in directory: %s", l.targetDir)
+	return "", fmt.Errorf("no OpenAPI spec file found in directory: %s", l.targetDir)
+}
+
+// parseSpec parses raw spec data. The location (nil when unknown) is used to
+// resolve relative external $refs, the files they point to are recorded in
+// files. Swagger 2.0 documents are converted to OpenAPI 3, in which case the
+// returned source version is "2.0".
+func parseSpec(data []byte, location *url.URL, files *fileRecorder) (*openapi3.T, string, error) {
+	// Swagger 2.0 documents are converted to OpenAPI 3 before grading
+	if detectSwagger(data) {
+		spec, err := convertSwagger(data, location, files)
+		if err != nil {
+			return nil, "", err
+		}
+		return spec, SwaggerVersion, nil
 	}
 
 	// Create a loader that can resolve external references
 	loader := openapi3.NewLoader()
 	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = files.read
 
-	// Load the spec from file (this will automatically resolve external $ref)
-	spec, err := loader.LoadFromFile(specFile)
+	// Load the spec (this will automatically resolve external $ref)
+	var spec *openapi3.T
+	var err error
+	if location != nil {
+		spec, err = loader.LoadFromDataWithPath(data, location)
+	} else {
+		spec, err = loader.LoadFromData(data)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse OpenAPI spec: %w", err)
+		return nil, "", fmt.Errorf("failed to parse OpenAPI spec: %w", err)
 	}
 
 	// Note: We skip strict validation to allow grading of specs with minor issues
 	// This allows SpecGrade to provide feedback on specs that have validation errors
 	// but are still structurally sound enough to analyze
 
-	return spec, nil
+	return spec, "", nil
+}
+
+// newSpecContext builds the evaluation context for a parsed spec
+func newSpecContext(spec *openapi3.T, version, sourceVersion string, source *SourceIndex) *core.SpecContext {
+	ctx := &core.SpecContext{
+		Spec:    spec,
+		Version: version,
+		Source:  source,
+	}
+
+	// Converted Swagger documents are graded as OpenAPI 3 but located in the original file
+	if sourceVersion == SwaggerVersion {
+		ctx.Version = ConvertedVersion
+		ctx.SourceVersion = SwaggerVersion
+		ctx.Source = &swaggerLocator{index: source}
+	}
+
+	return ctx
 }
 
 // fileExists checks if a file exists
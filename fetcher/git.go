@@ -0,0 +1,255 @@
+package fetcher
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrSpecNotInRevision is returned when the spec does not exist at the git revision
+var ErrSpecNotInRevision = errors.New("spec does not exist in revision")
+
+// GitSpecLoader loads the version of a local spec at the merge base of a git
+// ref and HEAD. Only the local repository is read, nothing is fetched.
+type GitSpecLoader struct {
+	input  string // Spec file or directory in the working tree
+	ref    string
+	commit string // Resolved merge base, set by LoadContext
+}
+
+// NewGitSpecLoader creates a loader for the spec at input as of the merge base of ref and HEAD
+func NewGitSpecLoader(input, ref string) *GitSpecLoader {
+	return &GitSpecLoader{
+		input: input,
+		ref:   ref,
+	}
+}
+
+// Commit returns the merge base the spec was loaded from
+func (l *GitSpecLoader) Commit() string {
+	return l.commit
+}
+
+// Load loads the OpenAPI spec from git history
+func (l *GitSpecLoader) Load(version string) (*openapi3.T, error) {
+	ctx, err := l.LoadContext(version)
+	if err != nil {
+		return nil, err
+	}
+	return ctx.Spec, nil
+}
+
+// LoadContext extracts the spec files of the repository at the merge base into
+// a temporary directory, so external $refs resolve as they did at the time,
+// and loads the spec from there
+func (l *GitSpecLoader) LoadContext(version string) (*core.SpecContext, error) {
+	absInput, err := filepath.Abs(l.input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve spec path: %w", err)
+	}
+
+	// Paths inside the repository are relative to its root
+	dir := absInput
+	if !isDir(absInput) {
+		dir = filepath.Dir(absInput)
+	}
+	root, err := git(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, fmt.Errorf("%s is not in a git repository: %w", l.input, err)
+	}
+	prefix, err := git(dir, "rev-parse", "--show-prefix")
+	if err != nil {
+		return nil, fmt.Errorf("%s is not in a git repository: %w", l.input, err)
+	}
+	relPath := filepath.Join(filepath.FromSlash(prefix), strings.TrimPrefix(absInput, dir))
+
+	commit, err := git(dir, "merge-base", l.ref, "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the merge base of %s and HEAD: %w", l.ref, err)
+	}
+	l.commit = commit
+
+	tmpDir, err := os.MkdirTemp("", "specgrade-since-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// The prefix is the directory of the spec, or the directory given as input
+	if err := extractSpecFiles(root, commit, path.Clean(prefix), tmpDir); err != nil {
+		return nil, err
+	}
+
+	oldPath := filepath.Join(tmpDir, relPath)
+	if _, err := os.Stat(oldPath); err != nil {
+		return nil, fmt.Errorf("%w: %s at %s", ErrSpecNotInRevision, l.input, l.ref)
+	}
+	if isDir(oldPath) {
+		return NewLocalSpecLoader(oldPath).LoadContext(version)
+	}
+	return NewFileSpecLoader(oldPath).LoadContext(version)
+}
+
+// extractSpecFiles writes the YAML and JSON files below specDir of a commit
+// into dir, followed by the files outside specDir they reference, e.g.
+// ../common/schemas.yaml. specDir is relative to the repository root, where
+// git archive has to run as it only includes the current directory.
+func extractSpecFiles(root, commit, specDir, dir string) error {
+	cmd := exec.Command("git", "archive", "--format=tar", commit, "--", specDir)
+	cmd.Dir = root
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	archive, err := cmd.Output()
+	if err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if strings.Contains(message, "did not match any files") {
+			return fmt.Errorf("%w: %s at %s", ErrSpecNotInRevision, specDir, commit)
+		}
+		return fmt.Errorf("failed to read %s from git: %s", commit, message)
+	}
+
+	var pending []string // Extracted files whose references are not resolved yet
+	reader := tar.NewReader(bytes.NewReader(archive))
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read git archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg || !isSpecFile(header.Name) {
+			continue
+		}
+
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return fmt.Errorf("failed to extract %s: %w", header.Name, err)
+		}
+		if err := writeSpecFile(dir, header.Name, data); err != nil {
+			return err
+		}
+		pending = append(pending, header.Name)
+	}
+
+	// Referenced files that do not exist at the commit are left to the loader to report
+	seen := make(map[string]bool)
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			continue
+		}
+		for _, ref := range externalRefs(data) {
+			target := path.Join(path.Dir(name), ref)
+			if seen[target] || strings.HasPrefix(target, "../") || !isSpecFile(target) {
+				continue
+			}
+			seen[target] = true
+			if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(target))); err == nil {
+				continue
+			}
+
+			content, err := gitFile(root, commit, target)
+			if err != nil {
+				continue
+			}
+			if err := writeSpecFile(dir, target, content); err != nil {
+				return err
+			}
+			pending = append(pending, target)
+		}
+	}
+	return nil
+}
+
+// writeSpecFile writes a file of the repository, named relative to its root, into dir
+func writeSpecFile(dir, name string, data []byte) error {
+	target := filepath.Join(dir, filepath.FromSlash(name))
+	if !strings.HasPrefix(target, filepath.Clean(dir)+string(os.PathSeparator)) {
+		return nil // Never write outside the temporary directory
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("failed to extract %s: %w", name, err)
+	}
+	if err := os.WriteFile(target, data, 0644); err != nil {
+		return fmt.Errorf("failed to extract %s: %w", name, err)
+	}
+	return nil
+}
+
+// externalRefs returns the files referenced by the $refs of a YAML or JSON
+// document, relative to the document; local and remote references are skipped
+func externalRefs(data []byte) []string {
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil
+	}
+
+	var refs []string
+	var walk func(node interface{})
+	walk = func(node interface{}) {
+		switch value := node.(type) {
+		case map[string]interface{}:
+			if ref, ok := value["$ref"].(string); ok {
+				file, _, _ := strings.Cut(ref, "#")
+				if file != "" && !strings.Contains(file, "://") {
+					refs = append(refs, file)
+				}
+			}
+			for _, child := range value {
+				walk(child)
+			}
+		case []interface{}:
+			for _, child := range value {
+				walk(child)
+			}
+		}
+	}
+	walk(doc)
+	return refs
+}
+
+// gitFile returns the content of a file, named relative to the repository root, at a commit
+func gitFile(root, commit, name string) ([]byte, error) {
+	cmd := exec.Command("git", "show", commit+":"+name)
+	cmd.Dir = root
+	return cmd.Output()
+}
+
+// isSpecFile reports whether a file may be (part of) an OpenAPI spec
+func isSpecFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+// git runs a git command in dir and returns its trimmed output
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", errors.New(message)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
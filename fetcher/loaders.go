@@ -0,0 +1,211 @@
+package fetcher
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// StdinInput is the input value that reads the spec from standard input
+const StdinInput = "-"
+
+// NewSpecLoader creates the loader matching an input: "-" reads from stdin,
+// http(s) URLs are downloaded, directories are searched for a well-known spec
+// file name and anything else is treated as a path to a spec file
+func NewSpecLoader(input string) core.ContextLoader {
+	switch {
+	case input == StdinInput:
+		return NewReaderSpecLoader("stdin", os.Stdin)
+	case IsURL(input):
+		return NewURLSpecLoader(input)
+	case isDir(input):
+		return NewLocalSpecLoader(input)
+	default:
+		return NewFileSpecLoader(input)
+	}
+}
+
+// FileSpecLoader loads an OpenAPI spec from an explicit file path
+type FileSpecLoader struct {
+	path string
+}
+
+// NewFileSpecLoader creates a loader for a single spec file
+func NewFileSpecLoader(path string) *FileSpecLoader {
+	return &FileSpecLoader{
+		path: path,
+	}
+}
+
+// Load loads the OpenAPI spec from the file
+func (l *FileSpecLoader) Load(version string) (*openapi3.T, error) {
+	ctx, err := l.LoadContext(version)
+	if err != nil {
+		return nil, err
+	}
+	return ctx.Spec, nil
+}
+
+// LoadContext loads the spec together with a source index of the file
+func (l *FileSpecLoader) LoadContext(version string) (*core.SpecContext, error) {
+	absPath, err := filepath.Abs(l.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve spec path: %w", err)
+	}
+
+	data, err := os.ReadFile(absPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI spec: %w", err)
+	}
+
+	files := newFileRecorder()
+	spec, sourceVersion, err := parseSpec(data, &url.URL{Path: filepath.ToSlash(absPath)}, files)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := newSourceIndex(absPath, filepath.Dir(absPath), data)
+	if err != nil {
+		return nil, err
+	}
+	source.addFiles(files)
+
+	return newSpecContext(spec, version, sourceVersion, source), nil
+}
+
+// ReaderSpecLoader loads an OpenAPI spec from a stream such as stdin. The stream
+// is read once; subsequent loads parse the buffered data again.
+type ReaderSpecLoader struct {
+	name   string
+	reader io.Reader
+
+	once    sync.Once
+	data    []byte
+	readErr error
+}
+
+// NewReaderSpecLoader creates a loader reading the spec from r; name is used
+// as the file name in finding locations
+func NewReaderSpecLoader(name string, r io.Reader) *ReaderSpecLoader {
+	return &ReaderSpecLoader{
+		name:   name,
+		reader: r,
+	}
+}
+
+// Load loads the OpenAPI spec from the reader
+func (l *ReaderSpecLoader) Load(version string) (*openapi3.T, error) {
+	ctx, err := l.LoadContext(version)
+	if err != nil {
+		return nil, err
+	}
+	return ctx.Spec, nil
+}
+
+// LoadContext loads the spec together with a source index of the streamed data
+func (l *ReaderSpecLoader) LoadContext(version string) (*core.SpecContext, error) {
+	l.once.Do(func() {
+		l.data, l.readErr = io.ReadAll(l.reader)
+	})
+	if l.readErr != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI spec from %s: %w", l.name, l.readErr)
+	}
+	if len(l.data) == 0 {
+		return nil, fmt.Errorf("no OpenAPI spec received on %s", l.name)
+	}
+
+	files := newFileRecorder()
+	spec, sourceVersion, err := parseSpec(l.data, nil, files)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := newSourceIndex(l.name, "", l.data)
+	if err != nil {
+		return nil, err
+	}
+	source.addFiles(files)
+
+	return newSpecContext(spec, version, sourceVersion, source), nil
+}
+
+// URLSpecLoader downloads an OpenAPI spec over http(s)
+type URLSpecLoader struct {
+	url    string
+	client *http.Client
+}
+
+// NewURLSpecLoader creates a loader for a spec served at the given URL
+func NewURLSpecLoader(specURL string) *URLSpecLoader {
+	return &URLSpecLoader{
+		url:    specURL,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Load downloads and parses the OpenAPI spec
+func (l *URLSpecLoader) Load(version string) (*openapi3.T, error) {
+	ctx, err := l.LoadContext(version)
+	if err != nil {
+		return nil, err
+	}
+	return ctx.Spec, nil
+}
+
+// LoadContext downloads the spec and indexes the downloaded document
+func (l *URLSpecLoader) LoadContext(version string) (*core.SpecContext, error) {
+	location, err := url.Parse(l.url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid spec URL %s: %w", l.url, err)
+	}
+
+	resp, err := l.client.Get(l.url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download OpenAPI spec: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download OpenAPI spec: %s returned %s", l.url, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI spec from %s: %w", l.url, err)
+	}
+
+	// Relative external $refs are resolved against the spec URL
+	files := newFileRecorder()
+	spec, sourceVersion, err := parseSpec(data, location, files)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := newSourceIndex(l.url, "", data)
+	if err != nil {
+		return nil, err
+	}
+	source.addFiles(files)
+
+	return newSpecContext(spec, version, sourceVersion, source), nil
+}
+
+// IsURL reports whether an input is an http(s) URL
+func IsURL(input string) bool {
+	return strings.HasPrefix(input, "http://") || strings.HasPrefix(input, "https://")
+}
+
+// isDir checks if a path exists and is a directory
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,344 @@
+package fetcher
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+// maxRefHops bounds how many $ref indirections are followed while resolving a single path
+const maxRefHops = 32
+
+// SourceIndex keeps the YAML/JSON node tree of a spec and the files it references,
+// so JSON paths can be resolved to the file, line and column they were declared at
+type SourceIndex struct {
+	root    string // absolute path of the root spec file
+	baseDir string // directory file names are reported relative to
+
+	mu        sync.Mutex
+	documents map[string]*yaml.Node // parsed documents keyed by absolute file path
+	contents  map[string][]byte     // bytes of the files the spec was loaded from, keyed the same way
+}
+
+// NewSourceIndex creates a source index rooted at the given spec file
+func NewSourceIndex(specFile string) (*SourceIndex, error) {
+	root, err := filepath.Abs(specFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve spec path: %w", err)
+	}
+
+	data, err := os.ReadFile(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec source %s: %w", root, err)
+	}
+
+	return newSourceIndex(root, filepath.Dir(root), data)
+}
+
+// newSourceIndex creates a source index from already loaded root document data.
+// Positions are reported relative to baseDir, or with the root name as-is when
+// baseDir is empty (e.g. for stdin or URLs).
+func newSourceIndex(root, baseDir string, data []byte) (*SourceIndex, error) {
+	index := &SourceIndex{
+		root:      root,
+		baseDir:   baseDir,
+		documents: make(map[string]*yaml.Node),
+		contents:  map[string][]byte{root: data},
+	}
+
+	// Parse the root document eagerly so syntax problems surface at load time
+	doc, err := parseDocument(root, data)
+	if err != nil {
+		return nil, err
+	}
+	index.documents[root] = doc
+
+	return index, nil
+}
+
+// Locate resolves a JSON path (e.g. "$.paths./users.get") to its source position.
+// External and local $refs are followed, so the reported file is the one the node
+// really lives in. When the full path does not exist, the deepest existing ancestor
+// is returned since that is where the missing element has to be added.
+func (s *SourceIndex) Locate(path string) (core.SourcePosition, bool) {
+	file := s.root
+	node, err := s.document(file)
+	if err != nil {
+		return core.SourcePosition{}, false
+	}
+
+	position := s.position(file, node)
+	rest := strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	hops := 0
+
+	for rest != "" {
+		node = unalias(node)
+
+		switch node.Kind {
+		case yaml.MappingNode:
+			key, value, remaining, found := matchKey(node, rest)
+			if !found {
+				ref := refOf(node)
+				if ref == "" || hops >= maxRefHops {
+					return position, true
+				}
+				hops++
+
+				refFile, target, ok := s.follow(file, ref)
+				if !ok {
+					return position, true
+				}
+				file, node = refFile, target
+				continue
+			}
+
+			position = s.position(file, key)
+			node = value
+			rest = remaining
+
+		case yaml.SequenceNode:
+			segment, remaining, _ := strings.Cut(rest, ".")
+			idx, err := strconv.Atoi(segment)
+			if err != nil || idx < 0 || idx >= len(node.Content) {
+				return position, true
+			}
+
+			node = node.Content[idx]
+			position = s.position(file, node)
+			rest = remaining
+
+		default:
+			return position, true
+		}
+	}
+
+	return position, true
+}
+
+// addFiles records the files the loader read to resolve external $refs
+func (s *SourceIndex) addFiles(files *fileRecorder) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for location, data := range files.files {
+		s.contents[location] = data
+	}
+}
+
+// ContentHash returns the hex sha256 of the bytes of every file the spec was
+// loaded from: the root document and the files its external $refs point to.
+// Files are named relative to the spec directory, so the hash does not depend
+// on where the spec is checked out.
+func (s *SourceIndex) ContentHash() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	byName := make(map[string][]byte, len(s.contents))
+	names := make([]string, 0, len(s.contents))
+	for location, data := range s.contents {
+		name := s.displayName(location)
+		byName[name] = data
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	hash := sha256.New()
+	for _, name := range names {
+		hash.Write([]byte(name))
+		hash.Write([]byte{0})
+		hash.Write(byName[name])
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// document returns the root node of a parsed file, parsing it on first use
+func (s *SourceIndex) document(file string) (*yaml.Node, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if doc, exists := s.documents[file]; exists {
+		return doc, nil
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec source %s: %w", file, err)
+	}
+
+	doc, err := parseDocument(file, data)
+	if err != nil {
+		return nil, err
+	}
+
+	s.documents[file] = doc
+	return doc, nil
+}
+
+// parseDocument parses YAML or JSON data into its root node
+func parseDocument(name string, data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to index spec source %s: %w", name, err)
+	}
+
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		return doc.Content[0], nil
+	}
+	return &doc, nil
+}
+
+// follow resolves a $ref relative to the file it appears in
+func (s *SourceIndex) follow(file, ref string) (string, *yaml.Node, bool) {
+	location, pointer, _ := strings.Cut(ref, "#")
+
+	target := file
+	if location != "" {
+		if strings.Contains(location, "://") || strings.Contains(file, "://") {
+			return "", nil, false // remote documents are not indexed
+		}
+		target = filepath.Clean(filepath.Join(filepath.Dir(file), location))
+	}
+
+	node, err := s.document(target)
+	if err != nil {
+		return "", nil, false
+	}
+
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		if token == "" {
+			continue
+		}
+		if unescaped, err := url.PathUnescape(token); err == nil {
+			token = unescaped
+		}
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+
+		node = unalias(node)
+		switch node.Kind {
+		case yaml.MappingNode:
+			_, value, found := lookupKey(node, token)
+			if !found {
+				return "", nil, false
+			}
+			node = value
+		case yaml.SequenceNode:
+			idx, err := strconv.Atoi(token)
+			if err != nil || idx < 0 || idx >= len(node.Content) {
+				return "", nil, false
+			}
+			node = node.Content[idx]
+		default:
+			return "", nil, false
+		}
+	}
+
+	return target, node, true
+}
+
+// position converts a node into a source position with a display-friendly file name
+func (s *SourceIndex) position(file string, node *yaml.Node) core.SourcePosition {
+	return core.SourcePosition{
+		File:   s.displayName(file),
+		Line:   node.Line,
+		Column: node.Column,
+	}
+}
+
+// displayName names a file relative to the base directory when there is one
+func (s *SourceIndex) displayName(file string) string {
+	if s.baseDir != "" {
+		if rel, err := filepath.Rel(s.baseDir, file); err == nil {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return file
+}
+
+// fileRecorder reads the files external $refs point to for the loader and
+// keeps their bytes, keyed by absolute file path or URL
+type fileRecorder struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+func newFileRecorder() *fileRecorder {
+	return &fileRecorder{files: make(map[string][]byte)}
+}
+
+// read is an openapi3.ReadFromURIFunc
+func (r *fileRecorder) read(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+	data, err := openapi3.DefaultReadFromURI(loader, location)
+	if err != nil {
+		return nil, err
+	}
+
+	key := location.String()
+	if location.Scheme == "" || location.Scheme == "file" {
+		key = filepath.FromSlash(location.Path)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.files[key] = data
+	return data, nil
+}
+
+// matchKey finds the mapping key that prefixes the remaining path. Keys may contain
+// dots themselves (e.g. "/v1/users.json"), so the longest matching key wins.
+func matchKey(node *yaml.Node, rest string) (*yaml.Node, *yaml.Node, string, bool) {
+	var bestKey, bestValue *yaml.Node
+	remaining := ""
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		if bestKey != nil && len(key) <= len(bestKey.Value) {
+			continue
+		}
+
+		if rest == key {
+			bestKey, bestValue, remaining = node.Content[i], node.Content[i+1], ""
+		} else if strings.HasPrefix(rest, key+".") {
+			bestKey, bestValue, remaining = node.Content[i], node.Content[i+1], rest[len(key)+1:]
+		}
+	}
+
+	return bestKey, bestValue, remaining, bestKey != nil
+}
+
+// lookupKey finds an exact key in a mapping node
+func lookupKey(node *yaml.Node, name string) (*yaml.Node, *yaml.Node, bool) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == name {
+			return node.Content[i], node.Content[i+1], true
+		}
+	}
+	return nil, nil, false
+}
+
+// refOf returns the $ref value of a mapping node, if any
+func refOf(node *yaml.Node) string {
+	if _, value, found := lookupKey(node, "$ref"); found && value.Kind == yaml.ScalarNode {
+		return value.Value
+	}
+	return ""
+}
+
+// unalias follows YAML aliases to the anchored node
+func unalias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	return node
+}
@@ -0,0 +1,113 @@
+package fetcher
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/invopop/yaml"
+)
+
+const (
+	// SwaggerVersion is the source version reported for converted Swagger documents
+	SwaggerVersion = "2.0"
+
+	// ConvertedVersion is the OpenAPI version Swagger documents are graded as after conversion
+	ConvertedVersion = "3.0.0"
+)
+
+// detectSwagger reports whether the raw document declares `swagger: "2.0"`
+func detectSwagger(data []byte) bool {
+	var header struct {
+		Swagger string `json:"swagger"`
+	}
+	if err := yaml.Unmarshal(data, &header); err != nil {
+		return false
+	}
+	return strings.HasPrefix(header.Swagger, "2.")
+}
+
+// convertSwagger parses a Swagger 2.0 document and converts it to OpenAPI 3
+func convertSwagger(data []byte, location *url.URL, files *fileRecorder) (*openapi3.T, error) {
+	var doc2 openapi2.T
+	if err := yaml.Unmarshal(data, &doc2); err != nil {
+		return nil, fmt.Errorf("failed to parse Swagger 2.0 spec: %w", err)
+	}
+
+	doc3, err := openapi2conv.ToV3(&doc2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert Swagger 2.0 spec to OpenAPI 3: %w", err)
+	}
+
+	// Resolve the converted refs (and any external files) relative to the original location
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = files.read
+	if err := loader.ResolveRefsIn(doc3, location); err != nil {
+		return nil, fmt.Errorf("failed to resolve references in converted Swagger 2.0 spec: %w", err)
+	}
+
+	return doc3, nil
+}
+
+// swaggerSectionPaths maps OpenAPI 3 sections produced by the conversion back
+// to the Swagger 2.0 sections they were converted from
+var swaggerSectionPaths = []struct {
+	openapi string
+	swagger string
+}{
+	{"$.components.schemas", "$.definitions"},
+	{"$.components.parameters", "$.parameters"},
+	{"$.components.requestBodies", "$.parameters"},
+	{"$.components.responses", "$.responses"},
+	{"$.components.securitySchemes", "$.securityDefinitions"},
+	{"$.servers", "$.host"},
+}
+
+// swaggerLocator resolves OpenAPI 3 JSON paths of a converted document against
+// the original Swagger 2.0 source, so findings point back to the original file
+type swaggerLocator struct {
+	index *SourceIndex
+}
+
+// Locate translates the path to its Swagger 2.0 equivalent and resolves it
+func (l *swaggerLocator) Locate(path string) (core.SourcePosition, bool) {
+	return l.index.Locate(swaggerPath(path))
+}
+
+// ContentHash identifies the original Swagger 2.0 files
+func (l *swaggerLocator) ContentHash() string {
+	return l.index.ContentHash()
+}
+
+// swaggerPath rewrites an OpenAPI 3 JSON path into the equivalent Swagger 2.0 path.
+// Constructs without a direct equivalent (e.g. requestBody) resolve to their
+// closest existing ancestor in the source.
+func swaggerPath(path string) string {
+	for _, section := range swaggerSectionPaths {
+		if path == section.openapi || strings.HasPrefix(path, section.openapi+".") {
+			path = section.swagger + strings.TrimPrefix(path, section.openapi)
+			break
+		}
+	}
+
+	// Response and parameter schemas live directly under the object in 2.0
+	// ("responses.200.schema" instead of "responses.200.content.<media type>.schema")
+	for {
+		start := strings.Index(path, ".content.")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(path[start+len(".content."):], ".schema")
+		if end < 0 {
+			break
+		}
+		path = path[:start] + path[start+len(".content.")+end:]
+	}
+
+	return path
+}
@@ -0,0 +1,230 @@
+package history
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/copyleftdev/specgrade/runner"
+)
+
+// DefaultDir is the history directory used by 'specgrade history' when none is configured
+const DefaultDir = ".specgrade/history"
+
+// timestampFormat names entry files so that they sort chronologically
+const timestampFormat = "20060102T150405.000000000Z"
+
+// Store keeps the history of grading runs in a directory, one subdirectory per
+// spec and one JSON file per run
+type Store struct {
+	dir string
+}
+
+// NewStore creates a history store in dir
+func NewStore(dir string) *Store {
+	return &Store{
+		dir: dir,
+	}
+}
+
+// Record compares a report with the previous run of the spec, saves it as the
+// latest run and returns the trends. Trends are nil for the first run.
+func (s *Store) Record(spec string, report *core.Report, now time.Time) (*core.QualityTrends, error) {
+	entries, err := s.Entries(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(spec, report, now)
+
+	var trends *core.QualityTrends
+	if len(entries) > 0 {
+		trends = Trends(&entries[len(entries)-1], entry)
+	}
+
+	if err := s.save(entry); err != nil {
+		return nil, err
+	}
+	return trends, nil
+}
+
+// NewEntry builds the history entry of a report
+func NewEntry(spec string, report *core.Report, now time.Time) *core.HistoryEntry {
+	entry := &core.HistoryEntry{
+		Spec:      spec,
+		Timestamp: now.UTC(),
+		Grade:     report.Grade,
+		Score:     report.Score,
+		Findings:  []string{},
+	}
+	for _, result := range report.Rules {
+		for _, finding := range append(result.Issues(), result.Known...) {
+			entry.Findings = append(entry.Findings, runner.Fingerprint(result.RuleID, finding))
+		}
+	}
+	entry.Issues = len(entry.Findings)
+	sort.Strings(entry.Findings)
+	return entry
+}
+
+// Trends compares a run with the previous run of the same spec
+func Trends(previous, current *core.HistoryEntry) *core.QualityTrends {
+	trends := &core.QualityTrends{
+		PreviousScore:  previous.Score,
+		ScoreChange:    current.Score - previous.Score,
+		TrendDirection: "stable",
+		FixedIssues:    countMissing(previous.Findings, current.Findings),
+		NewIssues:      countMissing(current.Findings, previous.Findings),
+	}
+	switch {
+	case trends.ScoreChange > 0:
+		trends.TrendDirection = "improving"
+	case trends.ScoreChange < 0:
+		trends.TrendDirection = "declining"
+	}
+	return trends
+}
+
+// Entries returns the recorded runs of a spec, oldest first
+func (s *Store) Entries(spec string) ([]core.HistoryEntry, error) {
+	dir := filepath.Join(s.dir, SpecKey(spec))
+	files, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	var entries []core.HistoryEntry
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		entry, err := readEntry(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, *entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp.Before(entries[j].Timestamp)
+	})
+	return entries, nil
+}
+
+// Specs returns the specs with a recorded history, sorted by name
+func (s *Store) Specs() ([]string, error) {
+	dirs, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	var specs []string
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		files, err := os.ReadDir(filepath.Join(s.dir, dir.Name()))
+		if err != nil {
+			continue
+		}
+
+		// Entries are named by timestamp, other files (e.g. .DS_Store) are ignored
+		latest := ""
+		for _, file := range files {
+			if !file.IsDir() && filepath.Ext(file.Name()) == ".json" {
+				latest = file.Name()
+			}
+		}
+		if latest == "" {
+			continue
+		}
+		entry, err := readEntry(filepath.Join(s.dir, dir.Name(), latest))
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, entry.Spec)
+	}
+	sort.Strings(specs)
+	return specs, nil
+}
+
+// SpecKey returns the directory name of a spec: a readable slug of the spec
+// identity followed by a hash, so different specs never share a directory
+func SpecKey(spec string) string {
+	slug := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '-'
+	}, spec)
+	slug = strings.Trim(slug, "-")
+	if len(slug) > 40 {
+		slug = slug[len(slug)-40:]
+	}
+
+	sum := sha256.Sum256([]byte(spec))
+	return strings.TrimLeft(slug+"-", "-") + hex.EncodeToString(sum[:4])
+}
+
+// save writes an entry to the directory of its spec
+func (s *Store) save(entry *core.HistoryEntry) error {
+	dir := filepath.Join(s.dir, SpecKey(entry.Spec))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal history entry: %w", err)
+	}
+
+	file := filepath.Join(dir, entry.Timestamp.Format(timestampFormat)+".json")
+	if err := os.WriteFile(file, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write history entry: %w", err)
+	}
+	return nil
+}
+
+// readEntry reads a single history entry
+func readEntry(path string) (*core.HistoryEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history entry: %w", err)
+	}
+	var entry core.HistoryEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to parse history entry %s: %w", path, err)
+	}
+	return &entry, nil
+}
+
+// countMissing counts the fingerprints of from that are not in to, respecting duplicates
+func countMissing(from, to []string) int {
+	remaining := make(map[string]int, len(to))
+	for _, fingerprint := range to {
+		remaining[fingerprint]++
+	}
+
+	missing := 0
+	for _, fingerprint := range from {
+		if remaining[fingerprint] > 0 {
+			remaining[fingerprint]--
+		} else {
+			missing++
+		}
+	}
+	return missing
+}
@@ -1,6 +1,9 @@
 package registry
 
 import (
+	"fmt"
+	"sort"
+
 	"github.com/copyleftdev/specgrade/core"
 )
 
@@ -46,3 +49,36 @@ func (r *RuleRegistry) GetRule(id string) core.Rule {
 	}
 	return nil
 }
+
+// Configure passes the options from the rules section of the config to the
+// rules accepting them. Settings for unknown rules, and options for rules that
+// take none, are reported as errors.
+func (r *RuleRegistry) Configure(settings map[string]core.RuleSettings) error {
+	ruleIDs := make([]string, 0, len(settings))
+	for ruleID := range settings {
+		ruleIDs = append(ruleIDs, ruleID)
+	}
+	sort.Strings(ruleIDs)
+
+	for _, ruleID := range ruleIDs {
+		rule := r.GetRule(ruleID)
+		if rule == nil {
+			return fmt.Errorf("unknown rule in config: %s", ruleID)
+		}
+
+		options := settings[ruleID].Options
+		if len(options) == 0 {
+			continue
+		}
+
+		configurable, ok := rule.(core.ConfigurableRule)
+		if !ok {
+			return fmt.Errorf("rule %s does not accept options", ruleID)
+		}
+		if err := configurable.Configure(options); err != nil {
+			return fmt.Errorf("invalid options for rule %s: %w", ruleID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,214 @@
+package reporter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/copyleftdev/specgrade/runner"
+)
+
+// codeQualityIssue is an issue of the GitLab Code Quality report
+type codeQualityIssue struct {
+	Description string              `json:"description"`
+	CheckName   string              `json:"check_name"`
+	Fingerprint string              `json:"fingerprint"`
+	Severity    string              `json:"severity"`
+	Location    codeQualityLocation `json:"location"`
+}
+
+type codeQualityLocation struct {
+	Path  string           `json:"path"`
+	Lines codeQualityLines `json:"lines"`
+}
+
+type codeQualityLines struct {
+	Begin int `json:"begin"`
+}
+
+// FormatGitHub outputs the findings as GitHub Actions workflow commands, so
+// they are shown as annotations on the lines of the pull request diff
+func (r *Reporter) FormatGitHub(report *core.Report, target string) string {
+	var output strings.Builder
+	writeGitHubAnnotations(&output, report, target)
+	output.WriteString(fmt.Sprintf("::notice title=SpecGrade::%s\n",
+		githubEscapeData(fmt.Sprintf("%s: grade %s (%d%%)", target, report.Grade, report.Score))))
+	return output.String()
+}
+
+// FormatMultiGitHub outputs the findings of all specs of a multi-spec run as GitHub Actions workflow commands
+func (r *Reporter) FormatMultiGitHub(multi *core.MultiReport) string {
+	var output strings.Builder
+	for _, spec := range multi.Specs {
+		if spec.Error != "" {
+			output.WriteString(fmt.Sprintf("::error file=%s,title=SpecGrade::%s\n", githubEscapeProperty(spec.Target), githubEscapeData(spec.Error)))
+			continue
+		}
+		if spec.Report != nil {
+			writeGitHubAnnotations(&output, spec.Report, spec.Target)
+		}
+	}
+	if multi.Aggregate != nil {
+		output.WriteString(fmt.Sprintf("::notice title=SpecGrade::%s\n",
+			githubEscapeData(fmt.Sprintf("%d specs: grade %s (%d%%)", multi.Aggregate.TotalSpecs, multi.Aggregate.Grade, multi.Aggregate.Score))))
+	}
+	return output.String()
+}
+
+// writeGitHubAnnotations writes one workflow command per finding. Known and
+// suppressed findings are left out.
+func writeGitHubAnnotations(output *strings.Builder, report *core.Report, target string) {
+	for _, result := range report.Rules {
+		for _, finding := range result.Issues() {
+			properties := []string{}
+			if file := findingFile(target, findingSourceFile(finding)); file != "" {
+				properties = append(properties, "file="+githubEscapeProperty(file))
+				if finding.Location != nil && finding.Location.Line > 0 {
+					properties = append(properties, fmt.Sprintf("line=%d", finding.Location.Line))
+					if finding.Location.Column > 0 {
+						properties = append(properties, fmt.Sprintf("col=%d", finding.Location.Column))
+					}
+				}
+			}
+			properties = append(properties, "title="+githubEscapeProperty(result.RuleID))
+
+			output.WriteString(fmt.Sprintf("::%s %s::%s\n", githubLevel(finding.Severity), strings.Join(properties, ","), githubEscapeData(finding.Detail)))
+		}
+	}
+}
+
+// FormatDiffGitHub outputs the changes of a diff as GitHub Actions workflow
+// commands: breaking changes as errors, the others as notices. Removals are
+// annotated in the old spec, everything else in the new one.
+func (r *Reporter) FormatDiffGitHub(diff *core.DiffReport) string {
+	var output strings.Builder
+	for _, change := range diff.Changes {
+		target := diff.New
+		if strings.HasSuffix(change.Type, "-removed") {
+			target = diff.Old
+		}
+
+		properties := []string{}
+		if change.Location != nil {
+			if file := findingFile(target, change.Location.File); file != "" {
+				properties = append(properties, "file="+githubEscapeProperty(file))
+				if change.Location.Line > 0 {
+					properties = append(properties, fmt.Sprintf("line=%d", change.Location.Line))
+					if change.Location.Column > 0 {
+						properties = append(properties, fmt.Sprintf("col=%d", change.Location.Column))
+					}
+				}
+			}
+		}
+		properties = append(properties, "title="+githubEscapeProperty(change.Type))
+
+		level := "notice"
+		if change.Breaking {
+			level = "error"
+		}
+		output.WriteString(fmt.Sprintf("::%s %s::%s\n", level, strings.Join(properties, ","), githubEscapeData(change.Description)))
+	}
+	output.WriteString(fmt.Sprintf("::notice title=SpecGrade::%s\n",
+		githubEscapeData(fmt.Sprintf("%s → %s: %d breaking, %d non-breaking changes", diff.Old, diff.New, diff.BreakingChanges, diff.NonBreakingChanges))))
+	return output.String()
+}
+
+// FormatGitLabCodeQuality outputs the findings as a GitLab Code Quality report
+func (r *Reporter) FormatGitLabCodeQuality(report *core.Report, target string) (string, error) {
+	return formatCodeQuality([]core.SpecReport{{Target: target, Report: report}})
+}
+
+// FormatMultiGitLabCodeQuality outputs the findings of all specs of a multi-spec run as a single GitLab Code Quality report
+func (r *Reporter) FormatMultiGitLabCodeQuality(multi *core.MultiReport) (string, error) {
+	return formatCodeQuality(multi.Specs)
+}
+
+func formatCodeQuality(specs []core.SpecReport) (string, error) {
+	issues := []codeQualityIssue{}
+	occurrences := make(map[string]int)
+	for _, spec := range specs {
+		if spec.Report == nil {
+			continue
+		}
+		for _, result := range spec.Report.Rules {
+			for _, finding := range result.Issues() {
+				file := findingFile(spec.Target, findingSourceFile(finding))
+				line := 1
+				if finding.Location != nil && finding.Location.Line > 0 {
+					line = finding.Location.Line
+				}
+
+				// Fingerprints must be unique across the report, so the file is part of it.
+				// A rule may report several findings at one path, e.g. one per undeclared
+				// scope of an operation, so the detail is too, and identical findings are
+				// told apart by their occurrence.
+				key := file + "\x00" + runner.Fingerprint(result.RuleID, finding) + "\x00" + finding.Detail
+				occurrence := occurrences[key]
+				occurrences[key]++
+				if occurrence > 0 {
+					key += fmt.Sprintf("\x00%d", occurrence)
+				}
+				sum := sha256.Sum256([]byte(key))
+
+				issues = append(issues, codeQualityIssue{
+					Description: finding.Detail,
+					CheckName:   result.RuleID,
+					Fingerprint: hex.EncodeToString(sum[:16]),
+					Severity:    codeQualitySeverity(finding.Severity),
+					Location:    codeQualityLocation{Path: file, Lines: codeQualityLines{Begin: line}},
+				})
+			}
+		}
+	}
+
+	data, err := json.MarshalIndent(issues, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	return string(data), nil
+}
+
+// findingSourceFile returns the source file of a finding, empty when its position is unknown
+func findingSourceFile(finding core.Finding) string {
+	if finding.Location == nil {
+		return ""
+	}
+	return finding.Location.File
+}
+
+// githubLevel maps a SpecGrade severity to a workflow command
+func githubLevel(severity string) string {
+	switch severity {
+	case "error":
+		return "error"
+	case "info":
+		return "notice"
+	default:
+		return "warning"
+	}
+}
+
+// codeQualitySeverity maps a SpecGrade severity to a Code Quality severity
+func codeQualitySeverity(severity string) string {
+	switch severity {
+	case "error":
+		return "major"
+	case "info":
+		return "info"
+	default:
+		return "minor"
+	}
+}
+
+// githubEscapeData escapes the message of a workflow command
+func githubEscapeData(value string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(value)
+}
+
+// githubEscapeProperty escapes a property value of a workflow command
+func githubEscapeProperty(value string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(value)
+}
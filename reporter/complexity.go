@@ -0,0 +1,248 @@
+package reporter
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Weights of the complexity score. Each factor adds points and the total is
+// capped at 100, so a spec with 50 operations is maximally complex on its own.
+const (
+	endpointWeight     = 2 // Per operation
+	schemaWeight       = 1 // Per component schema
+	parameterDivisor   = 2 // One point per two parameters or responses
+	nestingAllowance   = 3 // Nesting levels that add no complexity
+	nestingWeight      = 5 // Per nesting level beyond the allowance
+	circularRefWeight  = 5 // Per circular reference
+	externalRefWeight  = 1 // Per reference to another document
+	maxComplexityScore = 100
+)
+
+// complexityAnalyzer walks a spec once, counting its elements and references
+type complexityAnalyzer struct {
+	analysis *core.ComplexityAnalysis
+	depths   map[*openapi3.Schema]int  // Nesting depth of schemas already walked
+	walking  map[*openapi3.Schema]bool // Schemas on the current walk, to detect cycles
+}
+
+// AnalyzeComplexity measures the size and structure of a spec: operations,
+// component schemas, parameters and responses, the deepest schema nesting,
+// circular and external references, and a complexity score from 0 to 100
+func AnalyzeComplexity(spec *openapi3.T) *core.ComplexityAnalysis {
+	a := &complexityAnalyzer{
+		analysis: &core.ComplexityAnalysis{},
+		depths:   make(map[*openapi3.Schema]int),
+		walking:  make(map[*openapi3.Schema]bool),
+	}
+	if spec == nil {
+		return a.analysis
+	}
+
+	if spec.Components != nil {
+		a.analyzeComponents(spec.Components)
+	}
+	for _, path := range sortedKeys(spec.Paths) {
+		a.analyzePathItem(spec.Paths[path])
+	}
+
+	a.analysis.ComplexityScore = complexityScore(a.analysis)
+	return a.analysis
+}
+
+// complexityScore weighs the measured factors into a score from 0 to 100
+func complexityScore(analysis *core.ComplexityAnalysis) int {
+	score := analysis.EndpointCount*endpointWeight +
+		analysis.SchemaCount*schemaWeight +
+		(analysis.ParameterCount+analysis.ResponseCount)/parameterDivisor +
+		analysis.CircularRefs*circularRefWeight +
+		analysis.ExternalRefs*externalRefWeight
+	if analysis.NestingDepth > nestingAllowance {
+		score += (analysis.NestingDepth - nestingAllowance) * nestingWeight
+	}
+	if score > maxComplexityScore {
+		return maxComplexityScore
+	}
+	return score
+}
+
+func (a *complexityAnalyzer) analyzeComponents(components *openapi3.Components) {
+	a.analysis.SchemaCount = len(components.Schemas)
+	for _, name := range sortedKeys(components.Schemas) {
+		a.schema(components.Schemas[name])
+	}
+	for _, param := range components.Parameters {
+		a.parameter(param)
+	}
+	for _, header := range components.Headers {
+		a.header(header)
+	}
+	for _, body := range components.RequestBodies {
+		a.requestBody(body)
+	}
+	for _, response := range components.Responses {
+		a.response(response)
+	}
+	for _, scheme := range components.SecuritySchemes {
+		a.ref(scheme.Ref)
+	}
+	for _, example := range components.Examples {
+		a.ref(example.Ref)
+	}
+	for _, link := range components.Links {
+		a.ref(link.Ref)
+	}
+}
+
+func (a *complexityAnalyzer) analyzePathItem(item *openapi3.PathItem) {
+	if item == nil {
+		return
+	}
+	a.ref(item.Ref)
+
+	a.analysis.ParameterCount += len(item.Parameters)
+	for _, param := range item.Parameters {
+		a.parameter(param)
+	}
+
+	for _, operation := range item.Operations() {
+		a.analysis.EndpointCount++
+		a.analysis.ParameterCount += len(operation.Parameters)
+		for _, param := range operation.Parameters {
+			a.parameter(param)
+		}
+		a.requestBody(operation.RequestBody)
+
+		a.analysis.ResponseCount += len(operation.Responses)
+		for _, response := range operation.Responses {
+			a.response(response)
+		}
+	}
+}
+
+func (a *complexityAnalyzer) parameter(param *openapi3.ParameterRef) {
+	if param == nil {
+		return
+	}
+	a.ref(param.Ref)
+	if param.Value != nil {
+		a.schema(param.Value.Schema)
+		a.content(param.Value.Content)
+	}
+}
+
+func (a *complexityAnalyzer) header(header *openapi3.HeaderRef) {
+	if header == nil {
+		return
+	}
+	a.ref(header.Ref)
+	if header.Value != nil {
+		a.schema(header.Value.Schema)
+		a.content(header.Value.Content)
+	}
+}
+
+func (a *complexityAnalyzer) requestBody(body *openapi3.RequestBodyRef) {
+	if body == nil {
+		return
+	}
+	a.ref(body.Ref)
+	if body.Value != nil {
+		a.content(body.Value.Content)
+	}
+}
+
+func (a *complexityAnalyzer) response(response *openapi3.ResponseRef) {
+	if response == nil {
+		return
+	}
+	a.ref(response.Ref)
+	if response.Value == nil {
+		return
+	}
+	for _, header := range response.Value.Headers {
+		a.header(header)
+	}
+	a.content(response.Value.Content)
+}
+
+func (a *complexityAnalyzer) content(content openapi3.Content) {
+	for _, mediaType := range content {
+		if mediaType == nil {
+			continue
+		}
+		a.schema(mediaType.Schema)
+		for _, example := range mediaType.Examples {
+			a.ref(example.Ref)
+		}
+	}
+}
+
+// schema walks a schema and records the deepest nesting below it
+func (a *complexityAnalyzer) schema(ref *openapi3.SchemaRef) {
+	if depth := a.schemaDepth(ref); depth > a.analysis.NestingDepth {
+		a.analysis.NestingDepth = depth
+	}
+}
+
+// schemaDepth returns the nesting depth of a schema: 1 for a schema without
+// properties or items, one more for each level of properties, items or
+// additional properties. allOf, oneOf, anyOf and not combine schemas at the
+// same level. A reference back to a schema that is still being walked is a
+// circular reference; each one is counted once because every schema is
+// walked only once.
+func (a *complexityAnalyzer) schemaDepth(ref *openapi3.SchemaRef) int {
+	if ref == nil || ref.Value == nil {
+		return 0
+	}
+	a.ref(ref.Ref)
+
+	schema := ref.Value
+	if a.walking[schema] {
+		a.analysis.CircularRefs++
+		return 0
+	}
+	if depth, ok := a.depths[schema]; ok {
+		return depth
+	}
+	a.walking[schema] = true
+
+	nested := 0
+	for _, name := range sortedKeys(schema.Properties) {
+		nested = max(nested, a.schemaDepth(schema.Properties[name]))
+	}
+	nested = max(nested, a.schemaDepth(schema.Items))
+	nested = max(nested, a.schemaDepth(schema.AdditionalProperties.Schema))
+
+	depth := 1 + nested
+	for _, group := range []openapi3.SchemaRefs{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, member := range group {
+			depth = max(depth, a.schemaDepth(member))
+		}
+	}
+	depth = max(depth, a.schemaDepth(schema.Not))
+
+	delete(a.walking, schema)
+	a.depths[schema] = depth
+	return depth
+}
+
+// ref counts references to other documents. Local references start with #.
+func (a *complexityAnalyzer) ref(ref string) {
+	if ref != "" && !strings.HasPrefix(ref, "#") {
+		a.analysis.ExternalRefs++
+	}
+}
+
+// sortedKeys returns the keys of a map in a stable order, so the walk and with
+// it the depth measured through circular references is deterministic
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -16,7 +16,7 @@ func (r *Reporter) FormatDeveloperCLI(report *core.Report, targetDir string) str
 	output.WriteString("\n🚀 SpecGrade Developer Report\n")
 	output.WriteString("=" + strings.Repeat("=", 35) + "\n")
 	output.WriteString(fmt.Sprintf("📄 Target: %s\n", targetDir))
-	output.WriteString(fmt.Sprintf("🔖 OpenAPI Version: %s\n", report.Version))
+	output.WriteString(fmt.Sprintf("🔖 OpenAPI Version: %s%s\n", report.Version, convertedFrom(report)))
 	output.WriteString(fmt.Sprintf("🏅 Grade: %s (%d%%)\n", report.Grade, report.Score))
 
 	// Count issues by severity
@@ -34,6 +34,15 @@ func (r *Reporter) FormatDeveloperCLI(report *core.Report, targetDir string) str
 	if failed > 0 {
 		output.WriteString(fmt.Sprintf("❌ Failed: %d rules\n", failed))
 	}
+	if report.Baseline != nil {
+		output.WriteString(fmt.Sprintf("📌 Baseline: %s\n", baselineSummary(report.Baseline)))
+	}
+	if report.Since != nil {
+		output.WriteString(fmt.Sprintf("🔀 Since %s\n", sinceSummary(report.Since)))
+	}
+	if trends := qualityTrends(report); trends != nil {
+		output.WriteString(fmt.Sprintf("📈 Trend: %s\n", trendSummary(trends)))
+	}
 
 	// Detailed issues with file references and schema links
 	if failed > 0 {
@@ -44,7 +53,9 @@ func (r *Reporter) FormatDeveloperCLI(report *core.Report, targetDir string) str
 			if !result.Passed {
 				// Issue header with severity
 				severityIcon := "ℹ️"
-				if result.Severity == "error" {
+				if result.Errored {
+					severityIcon = "💥"
+				} else if result.Severity == "error" {
 					severityIcon = "❌"
 				} else if result.Severity == "warning" {
 					severityIcon = "⚠️"
@@ -53,6 +64,17 @@ func (r *Reporter) FormatDeveloperCLI(report *core.Report, targetDir string) str
 				output.WriteString(fmt.Sprintf("\n%s %s\n", severityIcon, result.RuleID))
 				output.WriteString(fmt.Sprintf("   Problem: %s\n", result.Detail))
 
+				// Individual findings, each with its own location
+				if len(result.Findings) > 0 {
+					output.WriteString(fmt.Sprintf("   📍 Findings (%d):\n", len(result.Findings)))
+					for _, finding := range result.Issues() {
+						output.WriteString(fmt.Sprintf("      • %s\n", finding.Detail))
+						if ref := findingLocation(finding); ref != "" {
+							output.WriteString(fmt.Sprintf("        📄 %s\n", ref))
+						}
+					}
+				}
+
 				// File location - most important for developers
 				if result.Location != nil {
 					if result.Location.FileRef != "" {
@@ -103,6 +125,19 @@ func (r *Reporter) FormatDeveloperCLI(report *core.Report, targetDir string) str
 		}
 	}
 
+	// Suppressed findings with the reason given in the spec
+	if len(report.Suppressions) > 0 {
+		output.WriteString(fmt.Sprintf("\n🔕 Suppressed (%d)\n", len(report.Suppressions)))
+		output.WriteString("=" + strings.Repeat("=", 15) + "\n")
+		for _, suppression := range report.Suppressions {
+			output.WriteString(fmt.Sprintf("• %s: %s\n", suppression.RuleID, suppression.Detail))
+			output.WriteString(fmt.Sprintf("   Reason: %s\n", suppression.Reason))
+			if ref := suppressionLocation(suppression); ref != "" {
+				output.WriteString(fmt.Sprintf("   📄 Declared: %s\n", ref))
+			}
+		}
+	}
+
 	// Simple next steps
 	output.WriteString("\n🎯 Next Steps\n")
 	output.WriteString("=" + strings.Repeat("=", 12) + "\n")
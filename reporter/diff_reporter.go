@@ -0,0 +1,206 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/copyleftdev/specgrade/core"
+)
+
+// FormatDiffJSON outputs the diff report in JSON format
+func (r *Reporter) FormatDiffJSON(diff *core.DiffReport) (string, error) {
+	data, err := json.MarshalIndent(diff, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	return string(data), nil
+}
+
+// FormatDiffCLI outputs the breaking changes followed by the non-breaking ones
+func (r *Reporter) FormatDiffCLI(diff *core.DiffReport) string {
+	var output strings.Builder
+
+	output.WriteString(fmt.Sprintf("🔀 Comparing: %s → %s\n", diff.Old, diff.New))
+	output.WriteString(fmt.Sprintf("💔 Breaking changes: %d\n", diff.BreakingChanges))
+	output.WriteString(fmt.Sprintf("✅ Non-breaking changes: %d\n", diff.NonBreakingChanges))
+
+	writeChanges := func(title string, breaking bool) {
+		if countChanges(diff, breaking) == 0 {
+			return
+		}
+		output.WriteString("\n" + title + "\n")
+		for _, change := range diff.Changes {
+			if change.Breaking != breaking {
+				continue
+			}
+			if ref := changeLocation(change); ref != "" {
+				output.WriteString(fmt.Sprintf("  - %s (%s)\n", change.Description, ref))
+			} else {
+				output.WriteString(fmt.Sprintf("  - %s\n", change.Description))
+			}
+		}
+	}
+	writeChanges("💔 Breaking Changes:", true)
+	writeChanges("📝 Non-breaking Changes:", false)
+
+	return output.String()
+}
+
+// FormatDiffDeveloperCLI outputs every change with its type and location
+func (r *Reporter) FormatDiffDeveloperCLI(diff *core.DiffReport) string {
+	var output strings.Builder
+
+	output.WriteString("\n🚀 SpecGrade Diff Report\n")
+	output.WriteString("=" + strings.Repeat("=", 35) + "\n")
+	output.WriteString(fmt.Sprintf("📄 Old: %s\n", diff.Old))
+	output.WriteString(fmt.Sprintf("📄 New: %s\n", diff.New))
+	output.WriteString(fmt.Sprintf("💔 Breaking: %d  ✅ Non-breaking: %d\n", diff.BreakingChanges, diff.NonBreakingChanges))
+
+	for _, change := range diff.Changes {
+		icon := "✅"
+		if change.Breaking {
+			icon = "💔"
+		}
+		output.WriteString(fmt.Sprintf("\n%s %s\n", icon, change.Type))
+		output.WriteString(fmt.Sprintf("   %s\n", change.Description))
+		if change.Location != nil {
+			if change.Location.FileRef != "" {
+				output.WriteString(fmt.Sprintf("   📄 File: %s\n", change.Location.FileRef))
+			}
+			if change.Location.Path != "" {
+				output.WriteString(fmt.Sprintf("   🔍 JSON Path: %s\n", change.Location.Path))
+			}
+		}
+	}
+
+	output.WriteString("\n" + strings.Repeat("=", 50) + "\n")
+	if diff.BreakingChanges > 0 {
+		output.WriteString("💡 Breaking changes need a new major API version or a deprecation period\n")
+	} else {
+		output.WriteString("🎉 No breaking changes, existing clients keep working.\n")
+	}
+
+	return output.String()
+}
+
+// FormatDiffMarkdown outputs the diff report in Markdown format
+func (r *Reporter) FormatDiffMarkdown(diff *core.DiffReport) string {
+	var output strings.Builder
+
+	output.WriteString("# SpecGrade Diff Report\n\n")
+	output.WriteString(fmt.Sprintf("**Old:** %s  \n", diff.Old))
+	output.WriteString(fmt.Sprintf("**New:** %s  \n", diff.New))
+	output.WriteString(fmt.Sprintf("**Breaking Changes:** %d  \n", diff.BreakingChanges))
+	output.WriteString(fmt.Sprintf("**Non-breaking Changes:** %d  \n", diff.NonBreakingChanges))
+
+	writeChanges := func(title string, breaking bool) {
+		if countChanges(diff, breaking) == 0 {
+			return
+		}
+		output.WriteString(fmt.Sprintf("\n## %s\n\n", title))
+		output.WriteString("| Type | Location | Change |\n")
+		output.WriteString("|------|----------|--------|\n")
+		for _, change := range diff.Changes {
+			if change.Breaking == breaking {
+				output.WriteString(fmt.Sprintf("| %s | `%s` | %s |\n", change.Type, changeLocation(change), change.Description))
+			}
+		}
+	}
+	writeChanges("Breaking Changes", true)
+	writeChanges("Non-breaking Changes", false)
+
+	return output.String()
+}
+
+// FormatDiffHTML outputs the diff report in HTML format
+func (r *Reporter) FormatDiffHTML(diff *core.DiffReport) string {
+	var rows strings.Builder
+	for _, change := range diff.Changes {
+		status := `<span class="passed">✅ Non-breaking</span>`
+		if change.Breaking {
+			status = `<span class="failed">💔 Breaking</span>`
+		}
+		rows.WriteString(fmt.Sprintf(`
+                <tr>
+                    <td>%s</td>
+                    <td>%s</td>
+                    <td>%s <code>%s</code></td>
+                </tr>`, status, change.Type, html.EscapeString(change.Description), html.EscapeString(changeLocation(change))))
+	}
+
+	return `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>SpecGrade Diff Report</title>
+    <style>
+        body { font-family: Arial, sans-serif; margin: 40px; background-color: #f5f5f5; }
+        .container { max-width: 800px; margin: 0 auto; background: white; padding: 30px; border-radius: 8px; box-shadow: 0 2px 10px rgba(0,0,0,0.1); }
+        .header { text-align: center; margin-bottom: 30px; }
+        .summary { display: flex; justify-content: space-around; margin: 30px 0; }
+        .summary div { text-align: center; }
+        .summary h3 { margin: 0; color: #666; }
+        .summary p { font-size: 1.5em; font-weight: bold; margin: 5px 0; }
+        table { width: 100%; border-collapse: collapse; margin-top: 20px; }
+        th, td { padding: 12px; text-align: left; border-bottom: 1px solid #ddd; }
+        th { background-color: #f8f9fa; font-weight: bold; }
+        .passed { color: #28a745; }
+        .failed { color: #dc3545; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>SpecGrade Diff Report</h1>
+            <p><strong>Old:</strong> ` + html.EscapeString(diff.Old) + `</p>
+            <p><strong>New:</strong> ` + html.EscapeString(diff.New) + `</p>
+        </div>
+
+        <div class="summary">
+            <div>
+                <h3>Breaking</h3>
+                <p class="failed">` + fmt.Sprintf("%d", diff.BreakingChanges) + `</p>
+            </div>
+            <div>
+                <h3>Non-breaking</h3>
+                <p class="passed">` + fmt.Sprintf("%d", diff.NonBreakingChanges) + `</p>
+            </div>
+        </div>
+
+        <table>
+            <thead>
+                <tr>
+                    <th>Status</th>
+                    <th>Type</th>
+                    <th>Change</th>
+                </tr>
+            </thead>
+            <tbody>` + rows.String() + `
+            </tbody>
+        </table>
+    </div>
+</body>
+</html>`
+}
+
+// countChanges counts the breaking or non-breaking changes
+func countChanges(diff *core.DiffReport, breaking bool) int {
+	if breaking {
+		return diff.BreakingChanges
+	}
+	return diff.NonBreakingChanges
+}
+
+// changeLocation returns the most precise reference available for a change
+func changeLocation(change core.Change) string {
+	if change.Location == nil {
+		return ""
+	}
+	if change.Location.FileRef != "" {
+		return change.Location.FileRef
+	}
+	return change.Location.Path
+}
@@ -1,15 +1,96 @@
 package reporter
 
 import (
+	"math"
+
 	"github.com/copyleftdev/specgrade/core"
 )
 
-// DefaultGrader implements the standard grading logic
-type DefaultGrader struct{}
+// ScoringFormula documents how the weighted score is calculated
+const ScoringFormula = "score = 100 × earned / (earned + penalty); earned = Σ weight of passed rules, penalty = Σ weight × severity multiplier of failed rules; a failed critical rule or a failure in a capped category caps the grade"
+
+// gradeScale lists the grades and their default minimum score, best grade first
+var gradeScale = []struct {
+	grade    string
+	minScore float64
+}{
+	{"A+", 95},
+	{"A", 90},
+	{"A-", 85},
+	{"B+", 80},
+	{"B", 75},
+	{"B-", 70},
+	{"C+", 65},
+	{"C", 60},
+	{"C-", 55},
+	{"D", 50},
+	{"F", 0},
+}
+
+// defaultThresholds returns the default minimum score of every grade
+func defaultThresholds() map[string]float64 {
+	thresholds := make(map[string]float64, len(gradeScale))
+	for _, step := range gradeScale {
+		thresholds[step.grade] = step.minScore
+	}
+	return thresholds
+}
+
+// DefaultScoringModel returns the built-in scoring model: a failed error costs
+// twice as much as a failed warning and an info half as much, and an API
+// without paths is capped at D. Failed rules are weighted by the severity
+// they report.
+func DefaultScoringModel() *core.ScoringModel {
+	return &core.ScoringModel{
+		SeverityMultipliers: map[string]float64{
+			"error":   2,
+			"warning": 1,
+			"info":    0.5,
+		},
+		CriticalRules: []string{"paths-exist"},
+		CriticalCap:   "D",
+	}
+}
 
-// NewDefaultGrader creates a new default grader
+// gradeThreshold is the minimum score of a grade within a profile
+type gradeThreshold struct {
+	grade    string
+	minScore float64
+}
+
+// DefaultGrader implements the standard weighted grading logic for a grading profile
+type DefaultGrader struct {
+	profile *core.GradingProfile
+	scale   []gradeThreshold
+}
+
+// NewDefaultGrader creates a new default grader using the default profile
 func NewDefaultGrader() *DefaultGrader {
-	return &DefaultGrader{}
+	return NewProfileGrader(BuiltinProfiles()[DefaultProfile])
+}
+
+// NewProfileGrader creates a grader for a grading profile. Grades without a
+// threshold in the profile keep their default minimum score.
+func NewProfileGrader(profile *core.GradingProfile) *DefaultGrader {
+	thresholds := mergeFloats(defaultThresholds(), profile.Thresholds)
+
+	scale := make([]gradeThreshold, 0, len(gradeScale))
+	for _, step := range gradeScale {
+		scale = append(scale, gradeThreshold{grade: step.grade, minScore: thresholds[step.grade]})
+	}
+
+	return &DefaultGrader{
+		profile: profile,
+		scale:   scale,
+	}
+}
+
+// Profile returns the grading profile, falling back to the default profile for a zero-value grader
+func (g *DefaultGrader) Profile() *core.GradingProfile {
+	if g.profile == nil {
+		return BuiltinProfiles()[DefaultProfile]
+	}
+	return g.profile
 }
 
 // Grade calculates a letter grade based on rule results
@@ -18,53 +99,160 @@ func (g *DefaultGrader) Grade(results []core.RuleResult) string {
 		return "F"
 	}
 
-	passed := 0
-	for _, result := range results {
-		if result.Passed {
-			passed++
-		}
+	breakdown := g.Breakdown(results)
+	grade := g.GradeForScore(breakdown.RawScore)
+	if breakdown.Cap != "" && gradeRank(grade) < gradeRank(breakdown.Cap) {
+		grade = breakdown.Cap
 	}
+	return grade
+}
 
-	percentage := float64(passed) / float64(len(results)) * 100
-
-	switch {
-	case percentage >= 95:
-		return "A+"
-	case percentage >= 90:
-		return "A"
-	case percentage >= 85:
-		return "A-"
-	case percentage >= 80:
-		return "B+"
-	case percentage >= 75:
-		return "B"
-	case percentage >= 70:
-		return "B-"
-	case percentage >= 65:
-		return "C+"
-	case percentage >= 60:
-		return "C"
-	case percentage >= 55:
-		return "C-"
-	case percentage >= 50:
-		return "D"
-	default:
-		return "F"
+// GradeForScore maps a 0-100 score onto the letter grade scale
+func (g *DefaultGrader) GradeForScore(percentage float64) string {
+	for _, step := range g.gradeScale() {
+		if percentage >= step.minScore {
+			return step.grade
+		}
 	}
+	return "F"
 }
 
-// CalculateScore returns the numeric score (0-100)
+// CalculateScore returns the numeric score (0-100). When the grade is capped,
+// the score is capped to the highest score of that grade.
 func (g *DefaultGrader) CalculateScore(results []core.RuleResult) int {
 	if len(results) == 0 {
 		return 0
 	}
 
-	passed := 0
+	breakdown := g.Breakdown(results)
+	score := breakdown.RawScore
+	if breakdown.Cap != "" {
+		score = math.Min(score, g.maxScoreForGrade(breakdown.Cap))
+	}
+	return int(score)
+}
+
+// Breakdown calculates the weighted score and records every rule's contribution.
+// Passed rules earn their weight and failed rules cost their weight times the
+// multiplier of their severity, so with equal weights and only warnings the score is
+// simply passed/total.
+func (g *DefaultGrader) Breakdown(results []core.RuleResult) *core.ScoreBreakdown {
+	profile := g.Profile()
+	model := profile.Scoring
+
+	critical := make(map[string]bool, len(model.CriticalRules))
+	for _, ruleID := range model.CriticalRules {
+		critical[ruleID] = true
+	}
+
+	breakdown := &core.ScoreBreakdown{
+		Profile:             profile.Name,
+		Formula:             ScoringFormula,
+		Thresholds:          g.thresholds(),
+		SeverityMultipliers: model.SeverityMultipliers,
+		Rules:               make([]core.RuleScore, 0, len(results)),
+	}
+
 	for _, result := range results {
+		weight, ok := model.RuleWeights[result.RuleID]
+		if !ok {
+			weight = 1
+		}
+
+		// The profile can override the severity the rule reports
+		severity := model.RuleSeverities[result.RuleID]
+		if severity == "" {
+			severity = result.Severity
+		}
+		if severity == "" {
+			severity = "warning"
+		}
+
+		multiplier, ok := model.SeverityMultipliers[severity]
+		if !ok {
+			multiplier = 1
+		}
+
+		score := core.RuleScore{
+			RuleID:     result.RuleID,
+			Weight:     weight,
+			Severity:   severity,
+			Multiplier: multiplier,
+			Critical:   critical[result.RuleID],
+		}
 		if result.Passed {
-			passed++
+			score.Earned = weight
+		} else {
+			score.Penalty = weight * multiplier
+			if score.Critical {
+				applyCap(breakdown, model.CriticalCap, result.RuleID)
+			}
+			if cap, ok := profile.CategoryCaps[result.Category]; ok {
+				applyCap(breakdown, cap, result.RuleID)
+			}
+		}
+
+		breakdown.EarnedPoints += score.Earned
+		breakdown.PenaltyPoints += score.Penalty
+		breakdown.Rules = append(breakdown.Rules, score)
+	}
+
+	if total := breakdown.EarnedPoints + breakdown.PenaltyPoints; total > 0 {
+		breakdown.RawScore = 100 * breakdown.EarnedPoints / total
+	}
+
+	return breakdown
+}
+
+// applyCap records a grade cap caused by a failed rule, keeping the lowest cap
+func applyCap(breakdown *core.ScoreBreakdown, cap, ruleID string) {
+	if gradeRank(cap) == len(gradeScale) {
+		return // Unknown grade, ignore the cap
+	}
+	if breakdown.Cap == "" || gradeRank(cap) > gradeRank(breakdown.Cap) {
+		breakdown.Cap = cap
+	}
+	breakdown.CappedBy = append(breakdown.CappedBy, ruleID)
+}
+
+// gradeScale returns the grade thresholds, falling back to the defaults for a zero-value grader
+func (g *DefaultGrader) gradeScale() []gradeThreshold {
+	if g.scale == nil {
+		return NewProfileGrader(g.Profile()).scale
+	}
+	return g.scale
+}
+
+// thresholds returns the minimum score of every grade
+func (g *DefaultGrader) thresholds() map[string]float64 {
+	scale := g.gradeScale()
+	thresholds := make(map[string]float64, len(scale))
+	for _, step := range scale {
+		thresholds[step.grade] = step.minScore
+	}
+	return thresholds
+}
+
+// maxScoreForGrade returns the highest score that still maps to the grade
+func (g *DefaultGrader) maxScoreForGrade(grade string) float64 {
+	scale := g.gradeScale()
+	for i, step := range scale {
+		if step.grade == grade {
+			if i == 0 {
+				return 100
+			}
+			return scale[i-1].minScore - 1
 		}
 	}
+	return 100
+}
 
-	return int(float64(passed) / float64(len(results)) * 100)
+// gradeRank returns the position of a grade on the scale (0 is the best grade)
+func gradeRank(grade string) int {
+	for i, step := range gradeScale {
+		if step.grade == grade {
+			return i
+		}
+	}
+	return len(gradeScale)
 }
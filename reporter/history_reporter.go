@@ -0,0 +1,48 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/copyleftdev/specgrade/core"
+)
+
+// historyBarWidth is the width of the score bar at 100%
+const historyBarWidth = 20
+
+// FormatHistoryJSON outputs the recorded runs in JSON format
+func (r *Reporter) FormatHistoryJSON(histories []core.SpecHistory) (string, error) {
+	data, err := json.MarshalIndent(histories, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	return string(data), nil
+}
+
+// FormatHistoryCLI outputs the score of each recorded run with the change since the previous run
+func (r *Reporter) FormatHistoryCLI(histories []core.SpecHistory) string {
+	var output strings.Builder
+
+	for i, spec := range histories {
+		if i > 0 {
+			output.WriteString("\n")
+		}
+		output.WriteString(fmt.Sprintf("📈 Score history: %s (%d runs)\n", spec.Spec, len(spec.Entries)))
+
+		for j, entry := range spec.Entries {
+			filled := entry.Score * historyBarWidth / 100
+			bar := strings.Repeat("█", filled) + strings.Repeat("░", historyBarWidth-filled)
+
+			change := ""
+			if j > 0 {
+				change = fmt.Sprintf("  %+d", entry.Score-spec.Entries[j-1].Score)
+			}
+
+			output.WriteString(fmt.Sprintf("  %s  %-2s %3d%% %s  %d findings%s\n",
+				entry.Timestamp.Local().Format("2006-01-02 15:04"), entry.Grade, entry.Score, bar, entry.Issues, change))
+		}
+	}
+
+	return output.String()
+}
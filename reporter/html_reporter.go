@@ -0,0 +1,318 @@
+package reporter
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// snippetContext is the number of source lines shown around a finding
+const snippetContext = 3
+
+// methodOrder sorts the operations of a path the way specs usually list them
+var methodOrder = map[string]int{"GET": 0, "PUT": 1, "POST": 2, "PATCH": 3, "DELETE": 4, "HEAD": 5, "OPTIONS": 6, "TRACE": 7}
+
+var htmlReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"lower": strings.ToLower,
+}).Parse(htmlReportPage))
+
+// htmlReport is the view model of the interactive HTML report
+type htmlReport struct {
+	Target       string
+	Version      string
+	Grade        string
+	GradeClass   string
+	Score        int
+	Passed       int
+	Total        int
+	Findings     int
+	Cards        template.HTML // Baseline, since and trend summaries
+	Severities   []string
+	Categories   []string
+	Rules        []htmlRule
+	Paths        []htmlPath
+	Suppressions []htmlSuppression
+	ToolVersion  string
+}
+
+type htmlRule struct {
+	ID         string
+	Status     string // passed, failed or errored
+	Severity   string
+	Category   string
+	Detail     string
+	Findings   []htmlFinding
+	Known      int
+	Suppressed int
+	Fix        *core.ActionableFix
+}
+
+type htmlFinding struct {
+	Detail   string
+	Severity string
+	Category string // May differ from the category of its rule
+	Location string
+	Path     string // JSON path, used to filter by operation
+	Snippet  []htmlSnippetLine
+}
+
+type htmlSnippetLine struct {
+	Number    int
+	Text      string
+	Highlight bool
+}
+
+type htmlPath struct {
+	Path       string
+	Key        string // JSON path of the path item
+	Issues     int    // Findings on the path and its operations
+	Operations []htmlOperation
+}
+
+type htmlOperation struct {
+	Method      string
+	OperationID string
+	Key         string // JSON path of the operation
+	Issues      int
+}
+
+type htmlSuppression struct {
+	RuleID   string
+	Detail   string
+	Location string
+	Reason   string
+}
+
+// FormatHTML outputs the report as a single, self-contained HTML page. The
+// page works offline: findings can be filtered by severity, category and
+// operation, each finding shows the spec source around its line, and failed
+// rules show their suggested fix. Source snippets are read from the spec files
+// on disk, so specs read from stdin or a URL have none.
+func (r *Reporter) FormatHTML(report *core.Report, targetDir string) string {
+	view := htmlReport{
+		Target:      targetDir,
+		Version:     report.Version,
+		Grade:       report.Grade,
+		GradeClass:  string(report.Grade[0]),
+		Score:       report.Score,
+		Passed:      r.countPassed(report.Rules),
+		Total:       len(report.Rules),
+		Findings:    r.countFindings(report.Rules),
+		Cards:       template.HTML(baselineHTML(report.Baseline) + sinceHTML(report.Since) + trendHTML(qualityTrends(report))),
+		ToolVersion: ToolVersion,
+	}
+
+	snippets := newSnippetReader(targetDir)
+	severities := map[string]bool{}
+	categories := map[string]bool{}
+
+	for _, result := range report.Rules {
+		rule := htmlRule{
+			ID:         result.RuleID,
+			Status:     "passed",
+			Severity:   result.Severity,
+			Category:   result.Category,
+			Detail:     result.Detail,
+			Known:      len(result.Known),
+			Suppressed: len(result.Suppressed),
+		}
+		switch {
+		case result.Errored:
+			rule.Status = "errored"
+		case !result.Passed:
+			rule.Status = "failed"
+			rule.Fix = result.Suggestion
+		}
+
+		for _, finding := range result.Issues() {
+			item := htmlFinding{
+				Detail:   finding.Detail,
+				Severity: finding.Severity,
+				Category: finding.Category,
+				Location: findingLocation(finding),
+			}
+			if finding.Location != nil {
+				item.Path = finding.Location.Path
+				item.Snippet = snippets.snippet(finding.Location.File, finding.Location.Line)
+			}
+			severities[item.Severity] = true
+			if item.Category != "" {
+				categories[item.Category] = true
+			}
+			rule.Findings = append(rule.Findings, item)
+		}
+
+		if rule.Severity != "" {
+			severities[rule.Severity] = true
+		}
+		if rule.Category != "" {
+			categories[rule.Category] = true
+		}
+		view.Rules = append(view.Rules, rule)
+	}
+
+	view.Severities = orderedSeverities(severities)
+	view.Categories = sortedKeys(categories)
+	view.Paths = operationTree(report)
+
+	for _, suppression := range report.Suppressions {
+		view.Suppressions = append(view.Suppressions, htmlSuppression{
+			RuleID:   suppression.RuleID,
+			Detail:   suppression.Detail,
+			Location: suppressionLocation(suppression),
+			Reason:   suppression.Reason,
+		})
+	}
+
+	var output strings.Builder
+	if err := htmlReportTemplate.Execute(&output, view); err != nil {
+		return fmt.Sprintf("<!DOCTYPE html><p>failed to render report: %s</p>", template.HTMLEscapeString(err.Error()))
+	}
+	return output.String()
+}
+
+// listOperations returns the operations of a spec sorted by path and method
+func listOperations(spec *openapi3.T) []core.OperationRef {
+	if spec == nil {
+		return nil
+	}
+
+	var operations []core.OperationRef
+	for _, path := range sortedKeys(spec.Paths) {
+		item := spec.Paths[path]
+		if item == nil {
+			continue
+		}
+		var pathOperations []core.OperationRef
+		for method, operation := range item.Operations() {
+			pathOperations = append(pathOperations, core.OperationRef{
+				Path:        path,
+				Method:      method,
+				OperationID: operation.OperationID,
+			})
+		}
+		sort.Slice(pathOperations, func(i, j int) bool {
+			return methodOrder[pathOperations[i].Method] < methodOrder[pathOperations[j].Method]
+		})
+		operations = append(operations, pathOperations...)
+	}
+	return operations
+}
+
+// operationTree groups the operations of a report by path and counts the
+// findings on each. A finding belongs to the longest path whose JSON path
+// prefixes its own, since paths may contain dots themselves.
+func operationTree(report *core.Report) []htmlPath {
+	var paths []htmlPath
+	index := map[string]int{}
+	for _, operation := range report.Operations {
+		i, ok := index[operation.Path]
+		if !ok {
+			i = len(paths)
+			index[operation.Path] = i
+			paths = append(paths, htmlPath{Path: operation.Path, Key: "$.paths." + operation.Path})
+		}
+		paths[i].Operations = append(paths[i].Operations, htmlOperation{
+			Method:      operation.Method,
+			OperationID: operation.OperationID,
+			Key:         paths[i].Key + "." + strings.ToLower(operation.Method),
+		})
+	}
+
+	for _, result := range report.Rules {
+		for _, finding := range result.Issues() {
+			if finding.Location == nil || finding.Location.Path == "" {
+				continue
+			}
+			jsonPath := finding.Location.Path
+
+			match := -1
+			for i, path := range paths {
+				if isJSONPathWithin(jsonPath, path.Key) && (match < 0 || len(path.Key) > len(paths[match].Key)) {
+					match = i
+				}
+			}
+			if match < 0 {
+				continue
+			}
+
+			paths[match].Issues++
+			for i, operation := range paths[match].Operations {
+				if isJSONPathWithin(jsonPath, operation.Key) {
+					paths[match].Operations[i].Issues++
+				}
+			}
+		}
+	}
+	return paths
+}
+
+// isJSONPathWithin reports whether a JSON path is the given node or below it
+func isJSONPathWithin(jsonPath, node string) bool {
+	return jsonPath == node || strings.HasPrefix(jsonPath, node+".")
+}
+
+// orderedSeverities lists the severities from most to least severe
+func orderedSeverities(severities map[string]bool) []string {
+	var ordered []string
+	for _, severity := range []string{"error", "warning", "info"} {
+		if severities[severity] {
+			ordered = append(ordered, severity)
+			delete(severities, severity)
+		}
+	}
+	return append(ordered, sortedKeys(severities)...)
+}
+
+// snippetReader reads the source lines around findings, caching each file
+type snippetReader struct {
+	target string
+	files  map[string][]string
+}
+
+func newSnippetReader(target string) *snippetReader {
+	return &snippetReader{
+		target: target,
+		files:  make(map[string][]string),
+	}
+}
+
+// snippet returns the lines around a line of a spec file, nil when the file
+// cannot be read
+func (s *snippetReader) snippet(file string, line int) []htmlSnippetLine {
+	if line <= 0 {
+		return nil
+	}
+	name := findingFile(s.target, file)
+	if name == "" {
+		return nil
+	}
+
+	lines, ok := s.files[name]
+	if !ok {
+		if data, err := os.ReadFile(name); err == nil {
+			lines = strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+		}
+		s.files[name] = lines
+	}
+	if line > len(lines) {
+		return nil
+	}
+
+	first := max(1, line-snippetContext)
+	last := min(len(lines), line+snippetContext)
+	snippet := make([]htmlSnippetLine, 0, last-first+1)
+	for number := first; number <= last; number++ {
+		snippet = append(snippet, htmlSnippetLine{
+			Number:    number,
+			Text:      lines[number-1],
+			Highlight: number == line,
+		})
+	}
+	return snippet
+}
@@ -0,0 +1,276 @@
+package reporter
+
+// htmlReportPage is the template of the HTML report. Styles and scripts are
+// inline so the report is a single file that works offline.
+const htmlReportPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>SpecGrade Report: {{.Target}}</title>
+    <style>
+        * { box-sizing: border-box; }
+        body { font-family: -apple-system, "Segoe UI", Arial, sans-serif; margin: 0; background: #f5f5f5; color: #222; }
+        header { background: white; padding: 24px 40px; box-shadow: 0 2px 10px rgba(0,0,0,0.1); display: flex; align-items: center; gap: 40px; flex-wrap: wrap; }
+        header h1 { margin: 0 0 6px; font-size: 1.4em; }
+        header p { margin: 2px 0; color: #555; }
+        .grade { font-size: 3em; font-weight: bold; }
+        .grade.A { color: #28a745; }
+        .grade.B { color: #17a2b8; }
+        .grade.C { color: #ffc107; }
+        .grade.D { color: #fd7e14; }
+        .grade.F { color: #dc3545; }
+        .summary { display: flex; gap: 32px; flex-wrap: wrap; }
+        .summary div { text-align: center; }
+        .summary h3 { margin: 0; color: #666; font-size: 0.9em; font-weight: normal; }
+        .summary p { font-size: 1.5em; font-weight: bold; margin: 4px 0; }
+        .summary small { color: #666; }
+        .layout { display: flex; gap: 24px; padding: 24px 40px; align-items: flex-start; }
+        nav { flex: 0 0 300px; background: white; border-radius: 8px; padding: 16px; box-shadow: 0 2px 10px rgba(0,0,0,0.1); position: sticky; top: 16px; max-height: calc(100vh - 32px); overflow: auto; }
+        nav h2 { font-size: 1em; margin: 0 0 8px; }
+        nav details { margin: 4px 0; }
+        nav summary { cursor: pointer; font-family: monospace; }
+        nav button { display: flex; width: 100%; align-items: center; gap: 6px; border: 0; background: none; padding: 4px 6px; margin: 2px 0; border-radius: 4px; cursor: pointer; text-align: left; font: inherit; }
+        nav button:hover { background: #f0f0f0; }
+        nav button.active { background: #e3f2fd; }
+        nav .op { padding-left: 16px; }
+        main { flex: 1; min-width: 0; }
+        .filters { background: white; border-radius: 8px; padding: 12px 16px; margin-bottom: 16px; box-shadow: 0 2px 10px rgba(0,0,0,0.1); display: flex; gap: 20px; flex-wrap: wrap; align-items: center; }
+        .filters input[type=search] { padding: 4px 8px; min-width: 220px; }
+        .rule { background: white; border-radius: 8px; margin-bottom: 10px; box-shadow: 0 1px 4px rgba(0,0,0,0.08); }
+        .rule > summary { padding: 12px 16px; cursor: pointer; display: flex; gap: 10px; align-items: baseline; flex-wrap: wrap; }
+        .rule-body { padding: 0 16px 16px; }
+        .rule-id { font-family: monospace; font-weight: bold; }
+        .detail { color: #555; }
+        .passed .status { color: #28a745; }
+        .failed .status { color: #dc3545; }
+        .errored .status { color: #6f42c1; }
+        .badge { display: inline-block; padding: 1px 8px; border-radius: 10px; font-size: 0.8em; background: #eee; color: #333; }
+        .badge.error { background: #f8d7da; color: #721c24; }
+        .badge.warning { background: #fff3cd; color: #856404; }
+        .badge.info { background: #d1ecf1; color: #0c5460; }
+        .count { margin-left: auto; color: #888; font-size: 0.85em; }
+        .method { font-family: monospace; font-size: 0.75em; font-weight: bold; min-width: 56px; }
+        .method.get { color: #28a745; } .method.post { color: #fd7e14; } .method.put { color: #17a2b8; }
+        .method.patch { color: #6f42c1; } .method.delete { color: #dc3545; }
+        .finding { border-left: 3px solid #ddd; margin: 8px 0; padding: 4px 10px; }
+        .finding.error { border-color: #dc3545; } .finding.warning { border-color: #ffc107; } .finding.info { border-color: #17a2b8; }
+        .finding > summary { cursor: pointer; }
+        .finding code { color: #666; }
+        pre { background: #272822; color: #f8f8f2; padding: 10px; border-radius: 6px; overflow: auto; font-size: 0.85em; margin: 8px 0; }
+        pre .line { display: block; }
+        pre .line.hl { background: rgba(255, 193, 7, 0.3); }
+        pre .ln { display: inline-block; width: 4em; color: #75715e; user-select: none; }
+        .fix { background: #f1f8e9; border-radius: 6px; padding: 10px 14px; margin-top: 12px; }
+        .fix h4 { margin: 0 0 6px; }
+        .fix p { margin: 4px 0; }
+        table { width: 100%; border-collapse: collapse; background: white; border-radius: 8px; }
+        th, td { padding: 10px; text-align: left; border-bottom: 1px solid #ddd; }
+        th { background-color: #f8f9fa; }
+        .empty { color: #888; padding: 20px; text-align: center; display: none; }
+        footer { color: #888; font-size: 0.8em; text-align: center; padding: 16px; }
+    </style>
+</head>
+<body>
+<header>
+    <div class="grade {{.GradeClass}}">{{.Grade}}</div>
+    <div>
+        <h1>SpecGrade Validation Report</h1>
+        <p><strong>Target:</strong> {{.Target}}</p>
+        <p><strong>OpenAPI Version:</strong> {{.Version}}</p>
+    </div>
+    <div class="summary">
+        <div>
+            <h3>Score</h3>
+            <p>{{.Score}}%</p>
+        </div>
+        <div>
+            <h3>Passed</h3>
+            <p>{{.Passed}}/{{.Total}}</p>
+        </div>
+        <div>
+            <h3>Findings</h3>
+            <p>{{.Findings}}</p>
+        </div>{{.Cards}}
+    </div>
+</header>
+
+<div class="layout">
+    <nav>
+        <h2>Paths</h2>
+        <button type="button" class="active" data-op="">All operations</button>
+        {{- range .Paths}}
+        <details open>
+            <summary>{{.Path}} <span class="count">{{.Issues}}</span></summary>
+            <button type="button" data-op="{{.Key}}">All methods <span class="count">{{.Issues}}</span></button>
+            {{- range .Operations}}
+            <button type="button" class="op" data-op="{{.Key}}" title="{{.OperationID}}">
+                <span class="method {{lower .Method}}">{{.Method}}</span>
+                <span>{{if .OperationID}}{{.OperationID}}{{end}}</span>
+                <span class="count">{{.Issues}}</span>
+            </button>
+            {{- end}}
+        </details>
+        {{- else}}
+        <p class="detail">No operations</p>
+        {{- end}}
+    </nav>
+
+    <main>
+        <div class="filters">
+            <span>
+                <strong>Severity:</strong>
+                {{- range .Severities}}
+                <label><input type="checkbox" class="severity-filter" value="{{.}}" checked> {{.}}</label>
+                {{- end}}
+            </span>
+            <label><strong>Category:</strong>
+                <select id="category-filter">
+                    <option value="">All</option>
+                    {{- range .Categories}}
+                    <option value="{{.}}">{{.}}</option>
+                    {{- end}}
+                </select>
+            </label>
+            <label><input type="checkbox" id="passed-filter"> Show passed rules</label>
+            <input type="search" id="search-filter" placeholder="Search findings">
+            <span id="visible-count" class="detail"></span>
+        </div>
+
+        {{- range .Rules}}
+        <details class="rule {{.Status}}" data-status="{{.Status}}" data-severity="{{.Severity}}" data-category="{{.Category}}"{{if eq .Status "failed"}} open{{end}}>
+            <summary>
+                <span class="status">{{if eq .Status "passed"}}✅{{else if eq .Status "errored"}}⚠️{{else}}❌{{end}}</span>
+                <span class="rule-id">{{.ID}}</span>
+                {{- if .Severity}} <span class="badge {{.Severity}}">{{.Severity}}</span>{{end}}
+                {{- if .Category}} <span class="badge">{{.Category}}</span>{{end}}
+                <span class="detail">{{.Detail}}</span>
+                <span class="count">
+                    {{- if .Findings}}{{len .Findings}} findings{{end}}
+                    {{- if .Known}} · {{.Known}} known{{end}}
+                    {{- if .Suppressed}} · {{.Suppressed}} suppressed{{end}}
+                </span>
+            </summary>
+            <div class="rule-body">
+                {{- range .Findings}}
+                <details class="finding {{.Severity}}" data-severity="{{.Severity}}" data-category="{{.Category}}" data-path="{{.Path}}">
+                    <summary>{{.Detail}}{{if .Location}} <code>{{.Location}}</code>{{end}}</summary>
+                    {{- if .Snippet}}
+                    <pre>{{range .Snippet}}<span class="line{{if .Highlight}} hl{{end}}"><span class="ln">{{.Number}}</span>{{.Text}}</span>{{end}}</pre>
+                    {{- end}}
+                </details>
+                {{- end}}
+                {{- with .Fix}}
+                <div class="fix">
+                    <h4>💡 {{.Title}}</h4>
+                    {{- if .Description}}
+                    <p>{{.Description}}</p>
+                    {{- end}}
+                    {{- if .Example}}
+                    <pre><code>{{.Example}}</code></pre>
+                    {{- end}}
+                    {{- if .SchemaRef}}
+                    <p>📖 <a href="{{.SchemaRef}}" target="_blank" rel="noopener">{{.SchemaRef}}</a></p>
+                    {{- end}}
+                </div>
+                {{- end}}
+            </div>
+        </details>
+        {{- end}}
+        <p class="empty" id="empty">No rules match the filters.</p>
+
+        {{- if .Suppressions}}
+        <h2>Suppressions</h2>
+        <table>
+            <thead>
+                <tr>
+                    <th>Rule ID</th>
+                    <th>Finding</th>
+                    <th>Reason</th>
+                </tr>
+            </thead>
+            <tbody>
+                {{- range .Suppressions}}
+                <tr>
+                    <td>{{.RuleID}}</td>
+                    <td>{{.Detail}} <code>{{.Location}}</code></td>
+                    <td>{{.Reason}}</td>
+                </tr>
+                {{- end}}
+            </tbody>
+        </table>
+        {{- end}}
+    </main>
+</div>
+
+<footer>Generated by SpecGrade {{.ToolVersion}}</footer>
+
+<script>
+(function () {
+    var rules = Array.prototype.slice.call(document.querySelectorAll(".rule"));
+    var opButtons = Array.prototype.slice.call(document.querySelectorAll("nav button"));
+    var severityFilters = Array.prototype.slice.call(document.querySelectorAll(".severity-filter"));
+    var categoryFilter = document.getElementById("category-filter");
+    var passedFilter = document.getElementById("passed-filter");
+    var searchFilter = document.getElementById("search-filter");
+    var visibleCount = document.getElementById("visible-count");
+    var empty = document.getElementById("empty");
+    var operation = "";
+
+    function within(path, node) {
+        return path === node || path.indexOf(node + ".") === 0;
+    }
+
+    function apply() {
+        var severities = {};
+        severityFilters.forEach(function (filter) { severities[filter.value] = filter.checked; });
+        var category = categoryFilter.value;
+        var query = searchFilter.value.toLowerCase();
+        var shownRules = 0, shownFindings = 0;
+
+        rules.forEach(function (rule) {
+            var findings = rule.querySelectorAll(".finding");
+            var visible = 0;
+            findings.forEach(function (finding) {
+                var show = severities[finding.dataset.severity] !== false &&
+                    (category === "" || finding.dataset.category === category) &&
+                    (operation === "" || within(finding.dataset.path, operation)) &&
+                    (query === "" || finding.textContent.toLowerCase().indexOf(query) >= 0);
+                finding.style.display = show ? "" : "none";
+                if (show) { visible++; }
+            });
+
+            var show;
+            if (findings.length > 0) {
+                show = visible > 0;
+            } else {
+                show = (category === "" || rule.dataset.category === category) &&
+                    (rule.dataset.status !== "passed" || passedFilter.checked) &&
+                    severities[rule.dataset.severity] !== false && operation === "" &&
+                    (query === "" || rule.textContent.toLowerCase().indexOf(query) >= 0);
+            }
+            rule.style.display = show ? "" : "none";
+            if (show) { shownRules++; shownFindings += visible; }
+        });
+
+        visibleCount.textContent = shownRules + " rules, " + shownFindings + " findings shown";
+        empty.style.display = shownRules === 0 ? "block" : "none";
+    }
+
+    opButtons.forEach(function (button) {
+        button.addEventListener("click", function () {
+            operation = button.dataset.op;
+            opButtons.forEach(function (other) { other.classList.toggle("active", other === button); });
+            apply();
+        });
+    });
+    severityFilters.forEach(function (filter) { filter.addEventListener("change", apply); });
+    categoryFilter.addEventListener("change", apply);
+    passedFilter.addEventListener("change", apply);
+    searchFilter.addEventListener("input", apply);
+    apply();
+})();
+</script>
+</body>
+</html>
+`
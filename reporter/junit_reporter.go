@@ -0,0 +1,149 @@
+package reporter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/copyleftdev/specgrade/core"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Cases      []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Body    string `xml:",chardata"`
+}
+
+// FormatJUnit outputs the report as JUnit XML: one testcase per rule, failed
+// rules carry their findings and the suggested fix
+func (r *Reporter) FormatJUnit(report *core.Report, target string) (string, error) {
+	return r.formatJUnit([]core.SpecReport{{Target: target, Report: report}})
+}
+
+// FormatMultiJUnit outputs a multi-spec run as JUnit XML with one testsuite per spec
+func (r *Reporter) FormatMultiJUnit(multi *core.MultiReport) (string, error) {
+	return r.formatJUnit(multi.Specs)
+}
+
+func (r *Reporter) formatJUnit(specs []core.SpecReport) (string, error) {
+	suites := junitTestSuites{Name: "SpecGrade"}
+	for _, spec := range specs {
+		suite := junitSuite(spec)
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Errors += suite.Errors
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	data, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JUnit XML: %w", err)
+	}
+	return xml.Header + string(data) + "\n", nil
+}
+
+// junitSuite converts the report of a spec into a testsuite. A spec that
+// failed to load is a suite with a single errored testcase.
+func junitSuite(spec core.SpecReport) junitTestSuite {
+	suite := junitTestSuite{Name: spec.Target}
+
+	if spec.Error != "" || spec.Report == nil {
+		suite.Tests, suite.Errors = 1, 1
+		suite.Cases = []junitTestCase{{
+			Name:      "load",
+			Classname: spec.Target,
+			Error:     &junitProblem{Message: spec.Error},
+		}}
+		return suite
+	}
+
+	report := spec.Report
+	suite.Properties = []junitProperty{
+		{Name: "grade", Value: report.Grade},
+		{Name: "score", Value: fmt.Sprintf("%d", report.Score)},
+		{Name: "openapi_version", Value: report.Version},
+	}
+
+	for _, result := range report.Rules {
+		testCase := junitTestCase{
+			Name:      result.RuleID,
+			Classname: spec.Target,
+		}
+
+		switch {
+		case result.Errored:
+			testCase.Error = &junitProblem{Message: result.Detail, Type: "errored"}
+			suite.Errors++
+		case !result.Passed:
+			testCase.Failure = &junitProblem{
+				Message: result.Detail,
+				Type:    result.Severity,
+				Body:    junitFailureBody(result),
+			}
+			suite.Failures++
+		default:
+			testCase.SystemOut = result.Detail
+		}
+
+		suite.Cases = append(suite.Cases, testCase)
+	}
+	suite.Tests = len(suite.Cases)
+
+	return suite
+}
+
+// junitFailureBody lists the findings of a failed rule followed by the suggested fix
+func junitFailureBody(result core.RuleResult) string {
+	var body strings.Builder
+
+	for _, finding := range result.Issues() {
+		body.WriteString("- " + finding.Detail)
+		if ref := findingLocation(finding); ref != "" {
+			body.WriteString(" (" + ref + ")")
+		}
+		body.WriteString("\n")
+	}
+
+	if result.Suggestion != nil {
+		body.WriteString("\nFix: " + result.Suggestion.Title + "\n")
+		if result.Suggestion.Description != "" {
+			body.WriteString(result.Suggestion.Description + "\n")
+		}
+		if result.Suggestion.SchemaRef != "" {
+			body.WriteString("See: " + result.Suggestion.SchemaRef + "\n")
+		}
+	}
+
+	return body.String()
+}
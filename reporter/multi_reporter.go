@@ -0,0 +1,198 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/copyleftdev/specgrade/core"
+)
+
+// GenerateMultiReport combines the per-spec reports of a multi-spec run and
+// computes an aggregate grade from the average score of the graded specs
+func (r *Reporter) GenerateMultiReport(specs []core.SpecReport) *core.MultiReport {
+	aggregate := &core.AggregateGrade{
+		TotalSpecs: len(specs),
+	}
+
+	graded := 0
+	totalScore := 0
+	for _, spec := range specs {
+		if spec.Passed {
+			aggregate.PassedSpecs++
+		} else {
+			aggregate.FailedSpecs++
+		}
+
+		if spec.Report == nil {
+			aggregate.ErrorSpecs++
+			continue
+		}
+
+		graded++
+		totalScore += spec.Report.Score
+		if spec.Report.Summary != nil {
+			aggregate.TotalIssues += spec.Report.Summary.TotalIssues
+		}
+	}
+
+	aggregate.Grade = "F"
+	if graded > 0 {
+		aggregate.Score = totalScore / graded
+		aggregate.Grade = r.grader.GradeForScore(float64(totalScore) / float64(graded))
+	}
+
+	return &core.MultiReport{
+		SchemaVersion: core.ReportSchemaVersion,
+		Specs:         specs,
+		Aggregate:     aggregate,
+	}
+}
+
+// FormatMultiJSON outputs the combined report in JSON format
+func (r *Reporter) FormatMultiJSON(multi *core.MultiReport) (string, error) {
+	data, err := json.MarshalIndent(multi, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	return string(data), nil
+}
+
+// FormatMultiCLI outputs a per-spec grade table followed by the aggregate grade
+func (r *Reporter) FormatMultiCLI(multi *core.MultiReport) string {
+	var output strings.Builder
+
+	output.WriteString(fmt.Sprintf("📦 Graded %d specs\n\n", multi.Aggregate.TotalSpecs))
+
+	for _, spec := range multi.Specs {
+		if spec.Report == nil {
+			output.WriteString(fmt.Sprintf("  💥 %-3s %4s  %s: %s\n", "ERR", "", spec.Target, spec.Error))
+			continue
+		}
+
+		status := "✅"
+		suffix := ""
+		if !spec.Passed {
+			status = "❌"
+			suffix = fmt.Sprintf(" (below threshold %s)", spec.Threshold)
+		}
+		output.WriteString(fmt.Sprintf("  %s %-3s %3d%%  %s%s\n", status, spec.Report.Grade, spec.Report.Score, spec.Target, suffix))
+	}
+
+	output.WriteString(fmt.Sprintf("\n🏅 Aggregate Grade: %s (%d%%)\n", multi.Aggregate.Grade, multi.Aggregate.Score))
+	output.WriteString(fmt.Sprintf("✅ Passed: %d/%d specs\n", multi.Aggregate.PassedSpecs, multi.Aggregate.TotalSpecs))
+	if multi.Aggregate.ErrorSpecs > 0 {
+		output.WriteString(fmt.Sprintf("💥 Failed to grade: %d specs\n", multi.Aggregate.ErrorSpecs))
+	}
+	output.WriteString(fmt.Sprintf("🔍 Total issues: %d\n", multi.Aggregate.TotalIssues))
+
+	return output.String()
+}
+
+// FormatMultiMarkdown outputs the combined report in Markdown format
+func (r *Reporter) FormatMultiMarkdown(multi *core.MultiReport) string {
+	var output strings.Builder
+
+	output.WriteString("# SpecGrade Validation Report\n\n")
+	output.WriteString(fmt.Sprintf("**Specs:** %d  \n", multi.Aggregate.TotalSpecs))
+	output.WriteString(fmt.Sprintf("**Aggregate Grade:** %s  \n", multi.Aggregate.Grade))
+	output.WriteString(fmt.Sprintf("**Aggregate Score:** %d%%  \n", multi.Aggregate.Score))
+	output.WriteString(fmt.Sprintf("**Passed:** %d/%d specs  \n\n", multi.Aggregate.PassedSpecs, multi.Aggregate.TotalSpecs))
+
+	output.WriteString("## Specs\n\n")
+	output.WriteString("| Spec | Grade | Score | Status | Issues |\n")
+	output.WriteString("|------|-------|-------|--------|--------|\n")
+
+	for _, spec := range multi.Specs {
+		if spec.Report == nil {
+			output.WriteString(fmt.Sprintf("| %s | - | - | 💥 %s | - |\n", spec.Target, spec.Error))
+			continue
+		}
+
+		status := "✅ Passed"
+		if !spec.Passed {
+			status = fmt.Sprintf("❌ Below %s", spec.Threshold)
+		}
+		issues := 0
+		if spec.Report.Summary != nil {
+			issues = spec.Report.Summary.TotalIssues
+		}
+		output.WriteString(fmt.Sprintf("| %s | %s | %d%% | %s | %d |\n", spec.Target, spec.Report.Grade, spec.Report.Score, status, issues))
+	}
+
+	return output.String()
+}
+
+// FormatMultiHTML outputs the combined report as a single HTML page
+func (r *Reporter) FormatMultiHTML(multi *core.MultiReport) string {
+	var rows strings.Builder
+
+	for _, spec := range multi.Specs {
+		if spec.Report == nil {
+			rows.WriteString(fmt.Sprintf(`
+                <tr>
+                    <td>%s</td>
+                    <td>-</td>
+                    <td>-</td>
+                    <td><span class="failed">💥 %s</span></td>
+                </tr>`, html.EscapeString(spec.Target), html.EscapeString(spec.Error)))
+			continue
+		}
+
+		status := `<span class="passed">✅ Passed</span>`
+		if !spec.Passed {
+			status = fmt.Sprintf(`<span class="failed">❌ Below %s</span>`, html.EscapeString(spec.Threshold))
+		}
+		rows.WriteString(fmt.Sprintf(`
+                <tr>
+                    <td>%s</td>
+                    <td>%s</td>
+                    <td>%d%%</td>
+                    <td>%s</td>
+                </tr>`, html.EscapeString(spec.Target), spec.Report.Grade, spec.Report.Score, status))
+	}
+
+	return `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>SpecGrade Validation Report</title>
+    <style>
+        body { font-family: Arial, sans-serif; margin: 40px; background-color: #f5f5f5; }
+        .container { max-width: 800px; margin: 0 auto; background: white; padding: 30px; border-radius: 8px; box-shadow: 0 2px 10px rgba(0,0,0,0.1); }
+        .header { text-align: center; margin-bottom: 30px; }
+        .grade { font-size: 3em; font-weight: bold; margin: 20px 0; }
+        table { width: 100%; border-collapse: collapse; margin-top: 20px; }
+        th, td { padding: 12px; text-align: left; border-bottom: 1px solid #ddd; }
+        th { background-color: #f8f9fa; font-weight: bold; }
+        .passed { color: #28a745; }
+        .failed { color: #dc3545; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>SpecGrade Validation Report</h1>
+            <p><strong>Specs:</strong> ` + fmt.Sprintf("%d/%d passed", multi.Aggregate.PassedSpecs, multi.Aggregate.TotalSpecs) + `</p>
+            <div class="grade">` + multi.Aggregate.Grade + `</div>
+            <p><strong>Aggregate Score:</strong> ` + fmt.Sprintf("%d%%", multi.Aggregate.Score) + `</p>
+        </div>
+
+        <table>
+            <thead>
+                <tr>
+                    <th>Spec</th>
+                    <th>Grade</th>
+                    <th>Score</th>
+                    <th>Status</th>
+                </tr>
+            </thead>
+            <tbody>` + rows.String() + `
+            </tbody>
+        </table>
+    </div>
+</body>
+</html>`
+}
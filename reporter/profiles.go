@@ -0,0 +1,218 @@
+package reporter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/copyleftdev/specgrade/core"
+)
+
+// DefaultProfile is the grading profile used when none is selected
+const DefaultProfile = "default"
+
+// BuiltinProfiles returns the grading profiles that ship with SpecGrade
+func BuiltinProfiles() map[string]*core.GradingProfile {
+	return map[string]*core.GradingProfile{
+		"default": {
+			Name:        "default",
+			Description: "Balanced grading for most APIs",
+			Thresholds:  defaultThresholds(),
+			Scoring:     *DefaultScoringModel(),
+		},
+		"strict": {
+			Name:        "strict",
+			Description: "Public and partner APIs: higher thresholds, errors cost more and schema or security gaps cap the grade",
+			Thresholds: map[string]float64{
+				"A+": 98, "A": 95, "A-": 92, "B+": 88, "B": 85,
+				"B-": 80, "C+": 75, "C": 70, "C-": 65, "D": 60,
+			},
+			CategoryCaps: map[string]string{
+				"schema":   "B",
+				"security": "C",
+			},
+			Scoring: core.ScoringModel{
+				SeverityMultipliers: map[string]float64{
+					"error":   3,
+					"warning": 1.5,
+					"info":    0.5,
+				},
+				CriticalRules: []string{"paths-exist", "info-title", "info-version"},
+				CriticalCap:   "D",
+			},
+		},
+		"lenient": {
+			Name:        "lenient",
+			Description: "Internal APIs: lower thresholds, warnings cost less and missing examples barely count",
+			Thresholds: map[string]float64{
+				"A+": 90, "A": 85, "A-": 80, "B+": 75, "B": 70,
+				"B-": 65, "C+": 60, "C": 55, "C-": 50, "D": 40,
+			},
+			Scoring: core.ScoringModel{
+				RuleWeights: map[string]float64{
+					"oas3-valid-schema-example": 0.5,
+				},
+				SeverityMultipliers: map[string]float64{
+					"error":   2,
+					"warning": 0.5,
+					"info":    0.25,
+				},
+				CriticalRules: []string{"paths-exist"},
+				CriticalCap:   "D",
+			},
+		},
+	}
+}
+
+// ResolveProfile looks up a grading profile by name. Custom profiles take
+// precedence over built-in ones and extend the built-in profile named in
+// Extends (the built-in profile of the same name, or default, when empty).
+// An empty name selects the default profile.
+func ResolveProfile(name string, custom map[string]core.GradingProfile) (*core.GradingProfile, error) {
+	if name == "" {
+		name = DefaultProfile
+	}
+
+	builtin := BuiltinProfiles()
+
+	override, ok := custom[name]
+	if !ok {
+		profile, ok := builtin[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown grading profile: %s (available: %s)", name, strings.Join(profileNames(builtin, custom), ", "))
+		}
+		return profile, nil
+	}
+
+	// A custom profile named after a built-in one adjusts that profile
+	baseName := override.Extends
+	if baseName == "" {
+		baseName = DefaultProfile
+		if _, ok := builtin[name]; ok {
+			baseName = name
+		}
+	}
+	base, ok := builtin[baseName]
+	if !ok {
+		return nil, fmt.Errorf("grading profile %s extends unknown built-in profile: %s", name, baseName)
+	}
+
+	profile := mergeProfile(base, &override)
+	profile.Name = name
+	if err := validateProfile(profile); err != nil {
+		return nil, fmt.Errorf("invalid grading profile %s: %w", name, err)
+	}
+	return profile, nil
+}
+
+// WithRuleSeverities returns a copy of the profile that weighs the given rules
+// with the severities configured in the rules section of the config
+func WithRuleSeverities(profile *core.GradingProfile, severities map[string]string) *core.GradingProfile {
+	if len(severities) == 0 {
+		return profile
+	}
+
+	adjusted := *profile
+	adjusted.Scoring.RuleSeverities = mergeStrings(profile.Scoring.RuleSeverities, severities)
+	return &adjusted
+}
+
+// mergeProfile applies the settings of override on top of base
+func mergeProfile(base, override *core.GradingProfile) *core.GradingProfile {
+	merged := &core.GradingProfile{
+		Description:  base.Description,
+		Extends:      base.Name,
+		Thresholds:   mergeFloats(base.Thresholds, override.Thresholds),
+		CategoryCaps: mergeStrings(base.CategoryCaps, override.CategoryCaps),
+		Scoring: core.ScoringModel{
+			RuleWeights:         mergeFloats(base.Scoring.RuleWeights, override.Scoring.RuleWeights),
+			RuleSeverities:      mergeStrings(base.Scoring.RuleSeverities, override.Scoring.RuleSeverities),
+			SeverityMultipliers: mergeFloats(base.Scoring.SeverityMultipliers, override.Scoring.SeverityMultipliers),
+			CriticalRules:       base.Scoring.CriticalRules,
+			CriticalCap:         base.Scoring.CriticalCap,
+		},
+	}
+
+	if override.Description != "" {
+		merged.Description = override.Description
+	}
+	if override.Scoring.CriticalRules != nil {
+		merged.Scoring.CriticalRules = override.Scoring.CriticalRules
+	}
+	if override.Scoring.CriticalCap != "" {
+		merged.Scoring.CriticalCap = override.Scoring.CriticalCap
+	}
+
+	return merged
+}
+
+// validateProfile checks that a profile only refers to known grades
+func validateProfile(profile *core.GradingProfile) error {
+	for grade := range profile.Thresholds {
+		if gradeRank(grade) == len(gradeScale) {
+			return fmt.Errorf("unknown grade in thresholds: %s", grade)
+		}
+	}
+	// Better grades must not require a lower score than worse ones
+	thresholds := mergeFloats(defaultThresholds(), profile.Thresholds)
+	for i := 1; i < len(gradeScale); i++ {
+		better, worse := gradeScale[i-1].grade, gradeScale[i].grade
+		if thresholds[better] < thresholds[worse] {
+			return fmt.Errorf("threshold of %s (%g) is below the threshold of %s (%g)", better, thresholds[better], worse, thresholds[worse])
+		}
+	}
+	for category, grade := range profile.CategoryCaps {
+		if gradeRank(grade) == len(gradeScale) {
+			return fmt.Errorf("unknown grade %s in category cap for %s", grade, category)
+		}
+	}
+	if cap := profile.Scoring.CriticalCap; cap != "" && gradeRank(cap) == len(gradeScale) {
+		return fmt.Errorf("unknown critical cap grade: %s", cap)
+	}
+	return nil
+}
+
+// profileNames lists the names of all available profiles
+func profileNames(builtin map[string]*core.GradingProfile, custom map[string]core.GradingProfile) []string {
+	var names []string
+	for name := range builtin {
+		names = append(names, name)
+	}
+	for name := range custom {
+		if _, ok := builtin[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// mergeFloats returns a copy of base with the entries of override applied
+func mergeFloats(base, override map[string]float64) map[string]float64 {
+	if len(base) == 0 && len(override) == 0 {
+		return nil
+	}
+	merged := make(map[string]float64, len(base)+len(override))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range override {
+		merged[key] = value
+	}
+	return merged
+}
+
+// mergeStrings returns a copy of base with the entries of override applied
+func mergeStrings(base, override map[string]string) map[string]string {
+	if len(base) == 0 && len(override) == 0 {
+		return nil
+	}
+	merged := make(map[string]string, len(base)+len(override))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range override {
+		merged[key] = value
+	}
+	return merged
+}
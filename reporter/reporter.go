@@ -1,47 +1,127 @@
 package reporter
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"html"
+	"path"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/copyleftdev/specgrade/core"
+	"github.com/copyleftdev/specgrade/runner"
+	"github.com/getkin/kin-openapi/openapi3"
 )
 
+// ToolVersion is the SpecGrade version recorded in reports
+const ToolVersion = "1.0.0"
+
 // Reporter handles different output formats
 type Reporter struct {
-	grader *DefaultGrader
+	grader core.Grader
 }
 
-// NewReporter creates a new reporter
+// NewReporter creates a new reporter using the default grading profile
 func NewReporter() *Reporter {
+	return NewReporterWithGrader(NewDefaultGrader())
+}
+
+// NewReporterWithGrader creates a reporter that grades with the given grader
+func NewReporterWithGrader(grader core.Grader) *Reporter {
 	return &Reporter{
-		grader: NewDefaultGrader(),
+		grader: grader,
 	}
 }
 
-// GenerateReport creates a report from rule results with enhanced developer analytics
-func (r *Reporter) GenerateReport(version string, results []core.RuleResult) *core.Report {
+// GenerateReport creates a report from the rule results of a spec with enhanced
+// developer analytics. The spec itself is analyzed for its complexity.
+func (r *Reporter) GenerateReport(spec *core.SpecContext, results []core.RuleResult) *core.Report {
 	grade := r.grader.Grade(results)
 	score := r.grader.CalculateScore(results)
 
 	// Generate enhanced analytics
 	summary := r.generateSummary(results)
-	analytics := r.generateAnalytics(results)
+	analytics := r.generateAnalytics(spec.Spec, results)
+
+	// Graders that can explain their score include the breakdown in the report
+	var scoring *core.ScoreBreakdown
+	if explainer, ok := r.grader.(core.ScoreExplainer); ok {
+		scoring = explainer.Breakdown(results)
+	}
+
+	metadata := map[string]string{
+		"generated_at": time.Now().UTC().Format(time.RFC3339),
+		"tool_version": ToolVersion,
+		"report_type":  "enhanced",
+	}
+	if hash := specHash(spec); hash != "" {
+		metadata["spec_hash"] = hash
+	}
 
 	return &core.Report{
-		Version:   version,
-		Grade:     grade,
-		Score:     score,
-		Rules:     results,
-		Summary:   summary,
-		Analytics: analytics,
-		Metadata: map[string]string{
-			"generated_at": "now", // Would use time.Now() in real implementation
-			"tool_version": "1.0.0",
-			"report_type":  "enhanced",
-		},
+		SchemaVersion: core.ReportSchemaVersion,
+		Version:       spec.Version,
+		Grade:         grade,
+		Score:         score,
+		Rules:         results,
+		Summary:       summary,
+		Analytics:     analytics,
+		Scoring:       scoring,
+		// Suppressed findings stay in the report so they remain auditable
+		Suppressions: collectSuppressed(results),
+		Operations:   listOperations(spec.Spec),
+		Metadata:     metadata,
+	}
+}
+
+// GenerateSinceReport generates the report of a spec compared with base, its
+// report at an earlier git revision, or nil when the spec did not exist then.
+// As with a baseline, the findings base already reported are known, so the
+// rules, grade and summary only count the findings introduced since. The grade
+// of the whole spec is kept in since, along with its change.
+func (r *Reporter) GenerateSinceReport(spec *core.SpecContext, results []core.RuleResult, base *core.Report, since *core.SinceSummary) *core.Report {
+	since.Grade = r.grader.Grade(results)
+	since.Score = r.grader.CalculateScore(results)
+
+	var existing []core.BaselineEntry
+	if base != nil {
+		existing = runner.BaselineEntries("", base.Rules)
+		since.BaseGrade = base.Grade
+		since.BaseScore = base.Score
+		since.ScoreDelta = since.Score - base.Score
+	}
+
+	introduced := append([]core.RuleResult(nil), results...)
+	summary := runner.ApplyBaseline(introduced, existing)
+	since.NewFindings = summary.New
+	since.FixedFindings = summary.Fixed
+
+	report := r.GenerateReport(spec, introduced)
+	report.Since = since
+	return report
+}
+
+// specHash identifies the content of a spec: the sha256 of every file it was
+// loaded from, including the files external $refs point to. Specs built in
+// memory have no files, the JSON form of the spec is hashed instead.
+func specHash(spec *core.SpecContext) string {
+	if hasher, ok := spec.Source.(core.ContentHasher); ok {
+		if hash := hasher.ContentHash(); hash != "" {
+			return "sha256:" + hash
+		}
+	}
+	if spec.Spec == nil {
+		return ""
 	}
+	data, err := json.Marshal(spec.Spec)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
 }
 
 // FormatJSON outputs the report in JSON format
@@ -58,7 +138,7 @@ func (r *Reporter) FormatCLI(report *core.Report, targetDir string) string {
 	var output strings.Builder
 
 	output.WriteString(fmt.Sprintf("📄 Validating: %s\n", targetDir))
-	output.WriteString(fmt.Sprintf("🔖 Spec: OpenAPI %s\n", report.Version))
+	output.WriteString(fmt.Sprintf("🔖 Spec: OpenAPI %s%s\n", report.Version, convertedFrom(report)))
 
 	passed := 0
 	for _, result := range report.Rules {
@@ -70,17 +150,56 @@ func (r *Reporter) FormatCLI(report *core.Report, targetDir string) string {
 	output.WriteString(fmt.Sprintf("✅ Passed: %d/%d rules\n", passed, len(report.Rules)))
 	output.WriteString(fmt.Sprintf("🎯 Score: %d%%\n", report.Score))
 	output.WriteString(fmt.Sprintf("🏅 Grade: %s\n", report.Grade))
+	if report.Scoring != nil && report.Scoring.Profile != "" && report.Scoring.Profile != DefaultProfile {
+		output.WriteString(fmt.Sprintf("📐 Profile: %s\n", report.Scoring.Profile))
+	}
+	if report.Scoring != nil && report.Scoring.Cap != "" {
+		output.WriteString(fmt.Sprintf("🧢 Grade capped at %s by: %s\n", report.Scoring.Cap, strings.Join(report.Scoring.CappedBy, ", ")))
+	}
+	if report.Baseline != nil {
+		output.WriteString(fmt.Sprintf("📌 Baseline: %s\n", baselineSummary(report.Baseline)))
+	}
+	if report.Since != nil {
+		output.WriteString(fmt.Sprintf("🔀 Since %s\n", sinceSummary(report.Since)))
+	}
+	if trends := qualityTrends(report); trends != nil {
+		output.WriteString(fmt.Sprintf("📈 Trend: %s\n", trendSummary(trends)))
+	}
 
-	// Show failed rules
+	// Show failed rules with their individual findings
 	if passed < len(report.Rules) {
 		output.WriteString("\n❌ Failed Rules:\n")
 		for _, result := range report.Rules {
 			if !result.Passed {
+				if result.Errored {
+					output.WriteString(fmt.Sprintf("  - %s: 💥 %s\n", result.RuleID, result.Detail))
+					continue
+				}
 				output.WriteString(fmt.Sprintf("  - %s: %s\n", result.RuleID, result.Detail))
+				if len(result.Findings) > 0 {
+					for _, finding := range result.Issues() {
+						if finding.Location != nil && finding.Location.FileRef != "" {
+							output.WriteString(fmt.Sprintf("      • %s (%s)\n", finding.Detail, finding.Location.FileRef))
+						} else {
+							output.WriteString(fmt.Sprintf("      • %s\n", finding.Detail))
+						}
+						if problem := finding.Metadata["suppression_error"]; problem != "" {
+							output.WriteString(fmt.Sprintf("        ⚠️  Not suppressed: %s\n", problem))
+						}
+					}
+				}
 			}
 		}
 	}
 
+	if len(report.Suppressions) > 0 {
+		output.WriteString(fmt.Sprintf("\n🔕 Suppressed Findings (%d):\n", len(report.Suppressions)))
+		for _, suppression := range report.Suppressions {
+			output.WriteString(fmt.Sprintf("  - %s: %s\n", suppression.RuleID, suppression.Detail))
+			output.WriteString(fmt.Sprintf("      Reason: %s (%s)\n", suppression.Reason, suppressionLocation(suppression)))
+		}
+	}
+
 	return output.String()
 }
 
@@ -103,7 +222,17 @@ func (r *Reporter) FormatMarkdown(report *core.Report, targetDir string) string
 
 	output.WriteString("## Summary\n\n")
 	output.WriteString(fmt.Sprintf("- **Passed:** %d/%d rules\n", passed, len(report.Rules)))
-	output.WriteString(fmt.Sprintf("- **Success Rate:** %d%%\n\n", report.Score))
+	output.WriteString(fmt.Sprintf("- **Success Rate:** %d%%\n", report.Score))
+	if report.Baseline != nil {
+		output.WriteString(fmt.Sprintf("- **Baseline:** %s\n", baselineSummary(report.Baseline)))
+	}
+	if report.Since != nil {
+		output.WriteString(fmt.Sprintf("- **Since:** %s\n", sinceSummary(report.Since)))
+	}
+	if trends := qualityTrends(report); trends != nil {
+		output.WriteString(fmt.Sprintf("- **Trend:** %s\n", trendSummary(trends)))
+	}
+	output.WriteString("\n")
 
 	output.WriteString("## Rule Results\n\n")
 	output.WriteString("| Rule ID | Status | Detail |\n")
@@ -117,94 +246,40 @@ func (r *Reporter) FormatMarkdown(report *core.Report, targetDir string) string
 		output.WriteString(fmt.Sprintf("| %s | %s | %s |\n", result.RuleID, status, result.Detail))
 	}
 
-	return output.String()
-}
+	// List individual findings so reviewers can jump to each offending location
+	if r.countFindings(report.Rules) > 0 {
+		output.WriteString("\n## Findings\n\n")
+		output.WriteString("| Rule ID | Severity | Location | Detail |\n")
+		output.WriteString("|---------|----------|----------|--------|\n")
 
-// FormatHTML outputs the report in HTML format
-func (r *Reporter) FormatHTML(report *core.Report, targetDir string) string {
-	var output strings.Builder
+		for _, result := range report.Rules {
+			for _, finding := range result.Issues() {
+				output.WriteString(fmt.Sprintf("| %s | %s | `%s` | %s |\n", result.RuleID, finding.Severity, findingLocation(finding), finding.Detail))
+			}
+		}
+	}
 
-	output.WriteString(`<!DOCTYPE html>
-<html lang="en">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>SpecGrade Validation Report</title>
-    <style>
-        body { font-family: Arial, sans-serif; margin: 40px; background-color: #f5f5f5; }
-        .container { max-width: 800px; margin: 0 auto; background: white; padding: 30px; border-radius: 8px; box-shadow: 0 2px 10px rgba(0,0,0,0.1); }
-        .header { text-align: center; margin-bottom: 30px; }
-        .grade { font-size: 3em; font-weight: bold; margin: 20px 0; }
-        .grade.A { color: #28a745; }
-        .grade.B { color: #17a2b8; }
-        .grade.C { color: #ffc107; }
-        .grade.D { color: #fd7e14; }
-        .grade.F { color: #dc3545; }
-        .summary { display: flex; justify-content: space-around; margin: 30px 0; }
-        .summary div { text-align: center; }
-        .summary h3 { margin: 0; color: #666; }
-        .summary p { font-size: 1.5em; font-weight: bold; margin: 5px 0; }
-        table { width: 100%; border-collapse: collapse; margin-top: 20px; }
-        th, td { padding: 12px; text-align: left; border-bottom: 1px solid #ddd; }
-        th { background-color: #f8f9fa; font-weight: bold; }
-        .passed { color: #28a745; }
-        .failed { color: #dc3545; }
-    </style>
-</head>
-<body>
-    <div class="container">
-        <div class="header">
-            <h1>SpecGrade Validation Report</h1>
-            <p><strong>Target:</strong> ` + targetDir + `</p>
-            <p><strong>OpenAPI Version:</strong> ` + report.Version + `</p>
-            <div class="grade ` + string(report.Grade[0]) + `">` + report.Grade + `</div>
-        </div>
-        
-        <div class="summary">
-            <div>
-                <h3>Score</h3>
-                <p>` + fmt.Sprintf("%d%%", report.Score) + `</p>
-            </div>
-            <div>
-                <h3>Passed</h3>
-                <p>` + fmt.Sprintf("%d/%d", r.countPassed(report.Rules), len(report.Rules)) + `</p>
-            </div>
-        </div>
-        
-        <h2>Rule Results</h2>
-        <table>
-            <thead>
-                <tr>
-                    <th>Rule ID</th>
-                    <th>Status</th>
-                    <th>Detail</th>
-                </tr>
-            </thead>
-            <tbody>`)
+	if len(report.Suppressions) > 0 {
+		output.WriteString("\n## Suppressions\n\n")
+		output.WriteString("| Rule ID | Declared At | Finding | Reason |\n")
+		output.WriteString("|---------|-------------|---------|--------|\n")
 
-	for _, result := range report.Rules {
-		status := `<span class="failed">❌ Failed</span>`
-		if result.Passed {
-			status = `<span class="passed">✅ Passed</span>`
+		for _, suppression := range report.Suppressions {
+			output.WriteString(fmt.Sprintf("| %s | `%s` | %s | %s |\n", suppression.RuleID, suppressionLocation(suppression), suppression.Detail, suppression.Reason))
 		}
-		output.WriteString(fmt.Sprintf(`
-                <tr>
-                    <td>%s</td>
-                    <td>%s</td>
-                    <td>%s</td>
-                </tr>`, result.RuleID, status, result.Detail))
 	}
 
-	output.WriteString(`
-            </tbody>
-        </table>
-    </div>
-</body>
-</html>`)
-
 	return output.String()
 }
 
+// convertedFrom describes the original format of a converted spec, if any
+func convertedFrom(report *core.Report) string {
+	if report.Metadata == nil || report.Metadata["source_version"] == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (converted from Swagger %s)", report.Metadata["source_version"])
+}
+
 // countPassed counts the number of passed rules
 func (r *Reporter) countPassed(results []core.RuleResult) int {
 	count := 0
@@ -216,6 +291,143 @@ func (r *Reporter) countPassed(results []core.RuleResult) int {
 	return count
 }
 
+// countFindings counts the individual findings across all failed rules
+func (r *Reporter) countFindings(results []core.RuleResult) int {
+	count := 0
+	for _, result := range results {
+		count += len(result.Issues())
+	}
+	return count
+}
+
+// findingLocation returns the most precise reference available for a finding
+func findingLocation(finding core.Finding) string {
+	if finding.Location == nil {
+		return ""
+	}
+	if finding.Location.FileRef != "" {
+		return finding.Location.FileRef
+	}
+	return finding.Location.Path
+}
+
+// findingFile returns the path of the file a finding is in, relative to the
+// working directory like the target. Source positions name files relative to
+// the directory of the spec, so they are joined with it. Specs read from stdin
+// or a URL have no file.
+func findingFile(target, file string) string {
+	if target == "stdin" || isRemoteTarget(target) {
+		return ""
+	}
+
+	name := filepath.ToSlash(target)
+	if file != "" {
+		dir := name
+		switch strings.ToLower(path.Ext(name)) {
+		case ".yaml", ".yml", ".json":
+			dir = path.Dir(name)
+		}
+		name = path.Join(dir, filepath.ToSlash(file))
+	}
+	return path.Clean(name)
+}
+
+// isRemoteTarget reports whether the spec was loaded from an http(s) URL
+func isRemoteTarget(target string) bool {
+	return strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://")
+}
+
+// collectSuppressed gathers the suppressed findings of all rules
+func collectSuppressed(results []core.RuleResult) []core.Suppression {
+	var suppressions []core.Suppression
+	for _, result := range results {
+		suppressions = append(suppressions, result.Suppressed...)
+	}
+	return suppressions
+}
+
+// baselineSummary describes how the findings compare to the baseline
+func baselineSummary(summary *core.BaselineSummary) string {
+	return fmt.Sprintf("%d new, %d known, %d fixed (%s)", summary.New, summary.Known, summary.Fixed, summary.File)
+}
+
+// baselineHTML renders the new findings for the HTML summary when a baseline is used
+func baselineHTML(summary *core.BaselineSummary) string {
+	if summary == nil {
+		return ""
+	}
+	return fmt.Sprintf(`
+            <div>
+                <h3>New Findings</h3>
+                <p>%d</p>
+                <small>%d known, %d fixed</small>
+            </div>`, summary.New, summary.Known, summary.Fixed)
+}
+
+// sinceSummary describes the grade delta and the findings introduced since a git revision
+func sinceSummary(since *core.SinceSummary) string {
+	commit := since.Commit
+	if len(commit) > 7 {
+		commit = commit[:7]
+	}
+	if since.BaseGrade == "" {
+		return fmt.Sprintf("%s (%s): new spec, %d new findings", since.Ref, commit, since.NewFindings)
+	}
+	return fmt.Sprintf("%s (%s): %s (%d%%) → %s (%d%%), %+d | %d new findings, %d fixed",
+		since.Ref, commit, since.BaseGrade, since.BaseScore, since.Grade, since.Score, since.ScoreDelta, since.NewFindings, since.FixedFindings)
+}
+
+// sinceHTML renders the grade delta for the HTML summary when comparing with a git revision
+func sinceHTML(since *core.SinceSummary) string {
+	if since == nil {
+		return ""
+	}
+	return fmt.Sprintf(`
+            <div>
+                <h3>Since %s</h3>
+                <p>%+d</p>
+                <small>%d new, %d fixed</small>
+            </div>`, html.EscapeString(since.Ref), since.ScoreDelta, since.NewFindings, since.FixedFindings)
+}
+
+// qualityTrends returns the comparison with the previous run, nil without history
+func qualityTrends(report *core.Report) *core.QualityTrends {
+	if report.Analytics == nil {
+		return nil
+	}
+	return report.Analytics.QualityTrends
+}
+
+// trendSummary describes the score change since the previous recorded run
+func trendSummary(trends *core.QualityTrends) string {
+	return fmt.Sprintf("%s, %+d since last run (%d%%) | %d new findings, %d fixed",
+		trends.TrendDirection, trends.ScoreChange, trends.PreviousScore, trends.NewIssues, trends.FixedIssues)
+}
+
+// trendHTML renders the score change for the HTML summary when history is recorded
+func trendHTML(trends *core.QualityTrends) string {
+	if trends == nil {
+		return ""
+	}
+	return fmt.Sprintf(`
+            <div>
+                <h3>Since Last Run</h3>
+                <p>%+d</p>
+                <small>%s, %d new, %d fixed</small>
+            </div>`, trends.ScoreChange, trends.TrendDirection, trends.NewIssues, trends.FixedIssues)
+}
+
+// suppressionLocation returns where a suppression is declared in the spec
+func suppressionLocation(suppression core.Suppression) string {
+	if suppression.Declared == nil {
+		return ""
+	}
+	if suppression.Declared.FileRef != "" {
+		return suppression.Declared.FileRef
+	}
+	return suppression.Declared.Path
+}
+
 // generateSummary creates a developer-focused summary of the validation results
 func (r *Reporter) generateSummary(results []core.RuleResult) *core.ReportSummary {
 	totalIssues := 0
@@ -229,13 +441,18 @@ func (r *Reporter) generateSummary(results []core.RuleResult) *core.ReportSummar
 	totalEstimatedMinutes := 0
 
 	for _, result := range results {
-		if !result.Passed {
+		if result.Passed {
+			continue
+		}
+
+		// Each finding of a failed rule is an individual issue
+		for _, finding := range result.Issues() {
 			totalIssues++
 
 			// Count by severity
-			if result.Severity != "" {
-				issuesBySeverity[result.Severity]++
-				if result.Severity == "error" {
+			if finding.Severity != "" {
+				issuesBySeverity[finding.Severity]++
+				if finding.Severity == "error" {
 					criticalIssues++
 				}
 			} else {
@@ -250,22 +467,22 @@ func (r *Reporter) generateSummary(results []core.RuleResult) *core.ReportSummar
 			}
 
 			// Identify quick wins based on severity
-			if result.Severity == "info" || result.Severity == "warning" {
+			if finding.Severity == "info" || finding.Severity == "warning" {
 				quickWins++
 			}
 
-			// Add to top priorities if high priority
-			if result.Metadata != nil && result.Metadata["fix_priority"] == "high" {
-				topPriorities = append(topPriorities, result.RuleID)
-			}
-
 			// Simple time estimation based on severity
-			if result.Severity == "error" {
+			if finding.Severity == "error" {
 				totalEstimatedMinutes += 10 // errors take longer
 			} else {
 				totalEstimatedMinutes += 5 // warnings/info are quicker
 			}
 		}
+
+		// Add to top priorities if high priority
+		if result.Metadata != nil && result.Metadata["fix_priority"] == "high" {
+			topPriorities = append(topPriorities, result.RuleID)
+		}
 	}
 
 	// Generate recommendations based on analysis
@@ -319,18 +536,8 @@ func (r *Reporter) generateSummary(results []core.RuleResult) *core.ReportSummar
 }
 
 // generateAnalytics creates detailed analytics about the API specification
-func (r *Reporter) generateAnalytics(results []core.RuleResult) *core.ReportAnalytics {
-	// For now, create basic analytics - in a real implementation this would analyze the actual spec
-	complexity := &core.ComplexityAnalysis{
-		EndpointCount:   2,  // Would count from actual spec
-		SchemaCount:     5,  // Would count from actual spec
-		ParameterCount:  8,  // Would count from actual spec
-		ResponseCount:   6,  // Would count from actual spec
-		ComplexityScore: 45, // Would calculate based on various factors
-		NestingDepth:    3,  // Would analyze schema nesting
-		CircularRefs:    0,  // Would detect circular references
-		ExternalRefs:    1,  // Would count external references
-	}
+func (r *Reporter) generateAnalytics(spec *openapi3.T, results []core.RuleResult) *core.ReportAnalytics {
+	complexity := AnalyzeComplexity(spec)
 
 	riskAssessment := &core.RiskAssessment{
 		SecurityRisks:    []string{},
@@ -343,13 +550,24 @@ func (r *Reporter) generateAnalytics(results []core.RuleResult) *core.ReportAnal
 	// Analyze results to determine risks
 	for _, result := range results {
 		if !result.Passed {
-			if result.Category == "security" {
-				riskAssessment.SecurityRisks = append(riskAssessment.SecurityRisks, result.Detail)
-				riskAssessment.OverallRiskLevel = "medium"
+			// Rules whose findings fall into several categories contribute each finding instead of their summary
+			risks := []core.Finding{{Detail: result.Detail, Category: result.Category}}
+			for _, finding := range result.Issues() {
+				if finding.Category != result.Category {
+					risks = result.Issues()
+					break
+				}
 			}
-			// Track compliance gaps based on category
-			if result.Category == "compliance" {
-				riskAssessment.ComplianceRisks = append(riskAssessment.ComplianceRisks, result.Detail)
+
+			for _, risk := range risks {
+				if risk.Category == "security" {
+					riskAssessment.SecurityRisks = append(riskAssessment.SecurityRisks, risk.Detail)
+					riskAssessment.OverallRiskLevel = "medium"
+				}
+				// Track compliance gaps based on category
+				if risk.Category == "compliance" {
+					riskAssessment.ComplianceRisks = append(riskAssessment.ComplianceRisks, risk.Detail)
+				}
 			}
 			if result.Severity == "error" {
 				riskAssessment.OverallRiskLevel = "medium"
@@ -0,0 +1,295 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/copyleftdev/specgrade/runner"
+)
+
+// SARIF 2.1.0, the format read by code scanning tools and SARIF viewers
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	// sarifFingerprint names the finding fingerprint in partialFingerprints
+	sarifFingerprint = "specgrade/v1"
+)
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool        sarifTool         `json:"tool"`
+	Invocations []sarifInvocation `json:"invocations"`
+	Results     []sarifResult     `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	HelpURI              string             `json:"helpUri,omitempty"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+	Properties           map[string]string  `json:"properties,omitempty"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifInvocation struct {
+	ExecutionSuccessful        bool                `json:"executionSuccessful"`
+	ToolExecutionNotifications []sarifNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+type sarifNotification struct {
+	Level      string              `json:"level"`
+	Message    sarifMessage        `json:"message"`
+	Descriptor *sarifDescriptorRef `json:"descriptor,omitempty"`
+}
+
+type sarifDescriptorRef struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID              string             `json:"ruleId"`
+	RuleIndex           int                `json:"ruleIndex"`
+	Level               string             `json:"level"`
+	Message             sarifMessage       `json:"message"`
+	Locations           []sarifLocation    `json:"locations,omitempty"`
+	PartialFingerprints map[string]string  `json:"partialFingerprints"`
+	BaselineState       string             `json:"baselineState,omitempty"`
+	Suppressions        []sarifSuppression `json:"suppressions,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+// FormatSARIF outputs the findings as a SARIF 2.1.0 log. Every registered rule
+// is described, whether or not it produced findings.
+func (r *Reporter) FormatSARIF(report *core.Report, target string, rules []core.Rule) (string, error) {
+	return r.formatSARIF([]core.SpecReport{{Target: target, Report: report}}, rules)
+}
+
+// FormatMultiSARIF outputs the findings of all specs of a multi-spec run as a single SARIF log
+func (r *Reporter) FormatMultiSARIF(multi *core.MultiReport, rules []core.Rule) (string, error) {
+	return r.formatSARIF(multi.Specs, rules)
+}
+
+func (r *Reporter) formatSARIF(specs []core.SpecReport, rules []core.Rule) (string, error) {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "SpecGrade",
+			Version:        ToolVersion,
+			InformationURI: "https://github.com/copyleftdev/specgrade",
+		}},
+		Invocations: []sarifInvocation{{ExecutionSuccessful: true}},
+		Results:     []sarifResult{},
+	}
+	invocation := &run.Invocations[0]
+
+	ruleIndex := make(map[string]int, len(rules))
+	for _, rule := range rules {
+		ruleIndex[rule.ID()] = len(run.Tool.Driver.Rules)
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:                   rule.ID(),
+			ShortDescription:     sarifMessage{Text: rule.Description()},
+			DefaultConfiguration: sarifConfiguration{Level: "warning"},
+		})
+	}
+
+	for _, spec := range specs {
+		if spec.Error != "" {
+			invocation.ExecutionSuccessful = false
+			invocation.ToolExecutionNotifications = append(invocation.ToolExecutionNotifications, sarifNotification{
+				Level:   "error",
+				Message: sarifMessage{Text: fmt.Sprintf("%s: %s", spec.Target, spec.Error)},
+			})
+			continue
+		}
+		if spec.Report == nil {
+			continue
+		}
+
+		// Known findings only exist when comparing with a baseline or a git revision
+		compared := spec.Report.Baseline != nil || spec.Report.Since != nil
+
+		for _, result := range spec.Report.Rules {
+			index, ok := ruleIndex[result.RuleID]
+			if !ok {
+				continue
+			}
+			describeSARIFRule(&run.Tool.Driver.Rules[index], result)
+
+			if result.Errored {
+				invocation.ToolExecutionNotifications = append(invocation.ToolExecutionNotifications, sarifNotification{
+					Level:      "error",
+					Message:    sarifMessage{Text: fmt.Sprintf("%s: %s", spec.Target, result.Detail)},
+					Descriptor: &sarifDescriptorRef{ID: result.RuleID},
+				})
+				continue
+			}
+
+			for _, finding := range result.Issues() {
+				sarif := newSARIFResult(result, index, finding, spec.Target)
+				if compared {
+					sarif.BaselineState = "new"
+				}
+				run.Results = append(run.Results, sarif)
+			}
+			for _, finding := range result.Known {
+				sarif := newSARIFResult(result, index, finding, spec.Target)
+				sarif.BaselineState = "unchanged"
+				run.Results = append(run.Results, sarif)
+			}
+			for _, suppression := range result.Suppressed {
+				finding := core.Finding{Detail: suppression.Detail, Severity: result.Severity, Location: suppression.Location}
+				sarif := newSARIFResult(result, index, finding, spec.Target)
+				sarif.Suppressions = []sarifSuppression{{Kind: "inSource", Justification: suppression.Reason}}
+				run.Results = append(run.Results, sarif)
+			}
+		}
+	}
+
+	log := sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []sarifRun{run},
+	}
+	data, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal SARIF: %w", err)
+	}
+	return string(data), nil
+}
+
+// describeSARIFRule completes a rule descriptor with what only its results
+// tell: the configured severity, the category and the link to the spec
+func describeSARIFRule(rule *sarifRule, result core.RuleResult) {
+	if result.Severity != "" {
+		rule.DefaultConfiguration.Level = sarifLevel(result.Severity)
+	}
+	if result.Category != "" {
+		rule.Properties = map[string]string{"category": result.Category}
+	}
+	if rule.HelpURI == "" && result.Suggestion != nil {
+		rule.HelpURI = result.Suggestion.SchemaRef
+	}
+}
+
+// newSARIFResult converts a finding into a SARIF result
+func newSARIFResult(result core.RuleResult, index int, finding core.Finding, target string) sarifResult {
+	severity := finding.Severity
+	if severity == "" {
+		severity = result.Severity
+	}
+
+	sarif := sarifResult{
+		RuleID:              result.RuleID,
+		RuleIndex:           index,
+		Level:               sarifLevel(severity),
+		Message:             sarifMessage{Text: finding.Detail},
+		PartialFingerprints: map[string]string{sarifFingerprint: runner.Fingerprint(result.RuleID, finding)},
+	}
+	if location := sarifResultLocation(finding.Location, target); location != nil {
+		sarif.Locations = []sarifLocation{*location}
+	}
+	return sarif
+}
+
+// sarifResultLocation converts a rule location into a SARIF location. Without
+// a source position the finding is reported against the spec file itself.
+func sarifResultLocation(location *core.RuleLocation, target string) *sarifLocation {
+	result := &sarifLocation{}
+
+	file := ""
+	if location != nil {
+		file = location.File
+		if location.Path != "" {
+			result.LogicalLocations = []sarifLogicalLocation{{FullyQualifiedName: location.Path, Kind: "member"}}
+		}
+	}
+	if uri := sarifArtifactURI(target, file); uri != "" {
+		result.PhysicalLocation = &sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: uri}}
+		if location != nil && location.Line > 0 {
+			result.PhysicalLocation.Region = &sarifRegion{StartLine: location.Line, StartColumn: location.Column}
+		}
+	}
+
+	if result.PhysicalLocation == nil && result.LogicalLocations == nil {
+		return nil
+	}
+	return result
+}
+
+// sarifArtifactURI returns the URI of the file a finding is in. Findings of
+// a spec loaded from a URL without a source position point at the URL.
+func sarifArtifactURI(target, file string) string {
+	if isRemoteTarget(target) && file == "" {
+		return target
+	}
+	name := findingFile(target, file)
+	if path.IsAbs(name) {
+		return "file://" + name
+	}
+	return name
+}
+
+// sarifLevel maps a SpecGrade severity to a SARIF level
+func sarifLevel(severity string) string {
+	switch severity {
+	case "error":
+		return "error"
+	case "info":
+		return "note"
+	default:
+		return "warning"
+	}
+}
@@ -3,6 +3,7 @@ package rules
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/copyleftdev/specgrade/core"
@@ -25,17 +26,24 @@ func (r *SchemaExampleConsistencyRule) AppliesTo(version string) bool {
 }
 
 func (r *SchemaExampleConsistencyRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
-	issues := []string{}
+	findings := []core.Finding{}
 
 	if ctx.Spec.Components != nil && ctx.Spec.Components.Schemas != nil {
-		for schemaName, schemaRef := range ctx.Spec.Components.Schemas {
+		schemaNames := make([]string, 0, len(ctx.Spec.Components.Schemas))
+		for schemaName := range ctx.Spec.Components.Schemas {
+			schemaNames = append(schemaNames, schemaName)
+		}
+		sort.Strings(schemaNames)
+
+		for _, schemaName := range schemaNames {
+			schemaRef := ctx.Spec.Components.Schemas[schemaName]
 			if schemaRef.Value != nil {
-				issues = append(issues, r.checkSchemaExamples(schemaName, schemaRef.Value, "")...)
+				findings = append(findings, r.checkSchemaExamples(schemaName, schemaRef.Value, "")...)
 			}
 		}
 	}
 
-	if len(issues) == 0 {
+	if len(findings) == 0 {
 		return core.RuleResult{
 			RuleID: r.ID(),
 			Passed: true,
@@ -43,36 +51,57 @@ func (r *SchemaExampleConsistencyRule) Evaluate(ctx *core.SpecContext) core.Rule
 		}
 	}
 
+	issues := make([]string, 0, min(3, len(findings)))
+	for _, finding := range findings[:min(3, len(findings))] {
+		issues = append(issues, finding.Detail)
+	}
+
 	return core.RuleResult{
-		RuleID: r.ID(),
-		Passed: false,
-		Detail: fmt.Sprintf("Found %d type/example mismatches: %s", len(issues), strings.Join(issues[:min(3, len(issues))], "; ")),
+		RuleID:   r.ID(),
+		Passed:   false,
+		Detail:   fmt.Sprintf("Found %d type/example mismatches: %s", len(findings), strings.Join(issues, "; ")),
+		Severity: "warning",
+		Category: "schema",
+		Findings: findings,
 	}
 }
 
-func (r *SchemaExampleConsistencyRule) checkSchemaExamples(schemaName string, schema *openapi3.Schema, path string) []string {
+func (r *SchemaExampleConsistencyRule) checkSchemaExamples(schemaName string, schema *openapi3.Schema, path string) []core.Finding {
 	return r.checkSchemaExamplesWithDepth(schemaName, schema, path, 0, make(map[*openapi3.Schema]bool))
 }
 
-func (r *SchemaExampleConsistencyRule) checkSchemaExamplesWithDepth(schemaName string, schema *openapi3.Schema, path string, depth int, visited map[*openapi3.Schema]bool) []string {
+func (r *SchemaExampleConsistencyRule) checkSchemaExamplesWithDepth(schemaName string, schema *openapi3.Schema, path string, depth int, visited map[*openapi3.Schema]bool) []core.Finding {
 	// Prevent infinite recursion with depth limit and cycle detection
 	if depth > 10 || visited[schema] {
-		return []string{}
+		return []core.Finding{}
 	}
 	visited[schema] = true
 	defer func() { visited[schema] = false }()
 
-	issues := []string{}
+	findings := []core.Finding{}
 	currentPath := schemaName
+	jsonPath := "$.components.schemas." + schemaName
 	if path != "" {
 		currentPath = fmt.Sprintf("%s.%s", schemaName, path)
+		jsonPath = fmt.Sprintf("%s.properties.%s", jsonPath, strings.ReplaceAll(path, ".", ".properties."))
 	}
 
 	// Check direct example
 	if schema.Example != nil && schema.Type != "" {
 		if !r.isExampleValidForType(schema.Example, schema.Type) {
-			issues = append(issues, fmt.Sprintf("%s: %s example %v doesn't match type %s",
-				currentPath, schema.Type, schema.Example, schema.Type))
+			findings = append(findings, core.Finding{
+				Detail: fmt.Sprintf("%s: %s example %v doesn't match type %s",
+					currentPath, schema.Type, schema.Example, schema.Type),
+				Location: &core.RuleLocation{
+					Path:        jsonPath + ".example",
+					Component:   schemaName,
+					SpecSection: "components",
+				},
+				Metadata: map[string]string{
+					"declared_type": schema.Type,
+					"example":       fmt.Sprintf("%v", schema.Example),
+				},
+			})
 		}
 	}
 
@@ -84,12 +113,12 @@ func (r *SchemaExampleConsistencyRule) checkSchemaExamplesWithDepth(schemaName s
 				if path != "" {
 					propPath = fmt.Sprintf("%s.%s", path, propName)
 				}
-				issues = append(issues, r.checkSchemaExamplesWithDepth(schemaName, propRef.Value, propPath, depth+1, visited)...)
+				findings = append(findings, r.checkSchemaExamplesWithDepth(schemaName, propRef.Value, propPath, depth+1, visited)...)
 			}
 		}
 	}
 
-	return issues
+	return findings
 }
 
 func (r *SchemaExampleConsistencyRule) isExampleValidForType(example interface{}, schemaType string) bool {
@@ -127,8 +156,14 @@ func (r *SchemaExampleConsistencyRule) isExampleValidForType(example interface{}
 	}
 }
 
+// DefaultMinDescriptionLength is the shortest operation description considered meaningful
+const DefaultMinDescriptionLength = 10
+
 // OperationDescriptionRule checks if operations have meaningful descriptions
-type OperationDescriptionRule struct{}
+type OperationDescriptionRule struct {
+	// MinLength is the minimum description length, DefaultMinDescriptionLength when zero
+	MinLength int
+}
 
 func (r *OperationDescriptionRule) ID() string {
 	return "operation-description"
@@ -142,6 +177,32 @@ func (r *OperationDescriptionRule) AppliesTo(version string) bool {
 	return strings.HasPrefix(version, "3.")
 }
 
+// Configure reads the min_length option
+func (r *OperationDescriptionRule) Configure(options core.RuleOptions) error {
+	if err := options.CheckKeys("min_length"); err != nil {
+		return err
+	}
+
+	minLength, err := options.Int("min_length", DefaultMinDescriptionLength)
+	if err != nil {
+		return err
+	}
+	if minLength < 1 {
+		return fmt.Errorf("min_length must be at least 1, got %d", minLength)
+	}
+
+	r.MinLength = minLength
+	return nil
+}
+
+// minLength returns the configured minimum description length
+func (r *OperationDescriptionRule) minLength() int {
+	if r.MinLength > 0 {
+		return r.MinLength
+	}
+	return DefaultMinDescriptionLength
+}
+
 func (r *OperationDescriptionRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
 	if len(ctx.Spec.Paths) == 0 {
 		return core.RuleResult{
@@ -151,35 +212,27 @@ func (r *OperationDescriptionRule) Evaluate(ctx *core.SpecContext) core.RuleResu
 		}
 	}
 
-	totalOps := 0
+	minLength := r.minLength()
 	missingDesc := 0
 	shortDesc := 0
-
-	for _, pathItem := range ctx.Spec.Paths {
-		operations := []struct {
-			method string
-			op     *openapi3.Operation
-		}{
-			{"GET", pathItem.Get},
-			{"POST", pathItem.Post},
-			{"PUT", pathItem.Put},
-			{"DELETE", pathItem.Delete},
-			{"PATCH", pathItem.Patch},
-			{"HEAD", pathItem.Head},
-			{"OPTIONS", pathItem.Options},
-		}
-
-		for _, op := range operations {
-			if op.op == nil {
-				continue
-			}
-			totalOps++
-
-			if op.op.Description == "" {
-				missingDesc++
-			} else if len(strings.TrimSpace(op.op.Description)) < 10 {
-				shortDesc++
-			}
+	var findings []core.Finding
+
+	for _, op := range specOperations(ctx.Spec) {
+		if op.op.Description == "" {
+			missingDesc++
+			findings = append(findings, core.Finding{
+				Detail:   fmt.Sprintf("%s %s has no description", op.method, op.path),
+				Location: operationLocation(op.path, op.method),
+			})
+		} else if len(strings.TrimSpace(op.op.Description)) < minLength {
+			shortDesc++
+			findings = append(findings, core.Finding{
+				Detail:   fmt.Sprintf("%s %s description is too short (< %d chars)", op.method, op.path, minLength),
+				Location: operationLocation(op.path, op.method),
+				Metadata: map[string]string{
+					"current_length": fmt.Sprintf("%d", len(strings.TrimSpace(op.op.Description))),
+				},
+			})
 		}
 	}
 
@@ -196,17 +249,21 @@ func (r *OperationDescriptionRule) Evaluate(ctx *core.SpecContext) core.RuleResu
 		issues = append(issues, fmt.Sprintf("%d missing descriptions", missingDesc))
 	}
 	if shortDesc > 0 {
-		issues = append(issues, fmt.Sprintf("%d too short (< 10 chars)", shortDesc))
+		issues = append(issues, fmt.Sprintf("%d too short (< %d chars)", shortDesc, minLength))
 	}
 
 	return core.RuleResult{
-		RuleID: r.ID(),
-		Passed: false,
-		Detail: fmt.Sprintf("Description issues: %s", strings.Join(issues, ", ")),
+		RuleID:   r.ID(),
+		Passed:   false,
+		Detail:   fmt.Sprintf("Description issues: %s", strings.Join(issues, ", ")),
+		Severity: "warning",
+		Category: "documentation",
+		Findings: findings,
 	}
 }
 
-// ErrorResponseRule checks if operations define proper error responses
+// ErrorResponseRule checks if operations define proper error responses. A
+// 4XX or 5XX range, or a default response, covers the codes it includes.
 type ErrorResponseRule struct{}
 
 func (r *ErrorResponseRule) ID() string {
@@ -233,30 +290,29 @@ func (r *ErrorResponseRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
 	totalOps := 0
 	missing400 := 0
 	missing500 := 0
+	var findings []core.Finding
 
-	for _, pathItem := range ctx.Spec.Paths {
-		operations := []*openapi3.Operation{
-			pathItem.Get, pathItem.Post, pathItem.Put,
-			pathItem.Delete, pathItem.Patch, pathItem.Head, pathItem.Options,
-		}
+	for _, op := range specOperations(ctx.Spec) {
+		totalOps++
 
-		for _, op := range operations {
-			if op == nil {
-				continue
-			}
-			totalOps++
+		missing := []string{}
+		if !declaresStatus(op.op.Responses, "400") {
+			missing400++
+			missing = append(missing, "400")
+		}
+		if !declaresStatus(op.op.Responses, "500") {
+			missing500++
+			missing = append(missing, "500")
+		}
 
-			if op.Responses != nil {
-				if _, has400 := op.Responses["400"]; !has400 {
-					missing400++
-				}
-				if _, has500 := op.Responses["500"]; !has500 {
-					missing500++
-				}
-			} else {
-				missing400++
-				missing500++
-			}
+		if len(missing) > 0 {
+			findings = append(findings, core.Finding{
+				Detail:   fmt.Sprintf("%s %s has no %s response", op.method, op.path, strings.Join(missing, " or ")),
+				Location: operationLocation(op.path, op.method),
+				Metadata: map[string]string{
+					"missing_responses": strings.Join(missing, ","),
+				},
+			})
 		}
 	}
 
@@ -289,8 +345,6 @@ func (r *ErrorResponseRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
 			Location: &core.RuleLocation{
 				Path:        "$.paths",
 				Component:   "operations",
-				File:        "openapi.yaml",
-				FileRef:     "openapi.yaml:paths section (operations missing error responses)",
 				SpecSection: "paths",
 			},
 			Suggestion: &core.ActionableFix{
@@ -329,6 +383,7 @@ func (r *ErrorResponseRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
 				"fix_priority":     "medium",
 				"error_type":       "missing_responses",
 			},
+			Findings: findings,
 		}
 	}
 
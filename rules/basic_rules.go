@@ -5,7 +5,6 @@ import (
 	"strings"
 
 	"github.com/copyleftdev/specgrade/core"
-	"github.com/getkin/kin-openapi/openapi3"
 )
 
 // InfoTitleRule checks if the OpenAPI spec has a title in the info section
@@ -34,8 +33,6 @@ func (r *InfoTitleRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
 			Location: &core.RuleLocation{
 				Path:        "$.info",
 				Component:   "info",
-				File:        "openapi.yaml", // or openapi.json
-				FileRef:     "openapi.yaml:1-10 (info section)",
 				SpecSection: "info",
 			},
 			Suggestion: &core.ActionableFix{
@@ -202,9 +199,10 @@ func (r *PathsExistRule) AppliesTo(version string) bool {
 func (r *PathsExistRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
 	if len(ctx.Spec.Paths) == 0 {
 		return core.RuleResult{
-			RuleID: r.ID(),
-			Passed: false,
-			Detail: "No paths defined",
+			RuleID:   r.ID(),
+			Passed:   false,
+			Detail:   "No paths defined",
+			Severity: "error",
 		}
 	}
 
@@ -239,38 +237,36 @@ func (r *OperationIDRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
 		}
 	}
 
-	operationIDs := make(map[string]bool)
+	firstSeen := make(map[string]string)
 	missingCount := 0
 	duplicateCount := 0
-
-	for _, pathItem := range ctx.Spec.Paths {
-		operations := []struct {
-			method string
-			op     *openapi3.Operation
-		}{
-			{"GET", pathItem.Get},
-			{"POST", pathItem.Post},
-			{"PUT", pathItem.Put},
-			{"DELETE", pathItem.Delete},
-			{"PATCH", pathItem.Patch},
-			{"HEAD", pathItem.Head},
-			{"OPTIONS", pathItem.Options},
+	var findings []core.Finding
+
+	for _, op := range specOperations(ctx.Spec) {
+		endpoint := fmt.Sprintf("%s %s", op.method, op.path)
+
+		if op.op.OperationID == "" {
+			missingCount++
+			findings = append(findings, core.Finding{
+				Detail:   fmt.Sprintf("%s is missing an operation ID", endpoint),
+				Location: operationLocation(op.path, op.method),
+			})
+			continue
 		}
 
-		for _, op := range operations {
-			if op.op == nil {
-				continue
-			}
-
-			if op.op.OperationID == "" {
-				missingCount++
-			} else {
-				if operationIDs[op.op.OperationID] {
-					duplicateCount++
-				}
-				operationIDs[op.op.OperationID] = true
-			}
+		if first, exists := firstSeen[op.op.OperationID]; exists {
+			duplicateCount++
+			findings = append(findings, core.Finding{
+				Detail:   fmt.Sprintf("%s reuses operation ID '%s' already used by %s", endpoint, op.op.OperationID, first),
+				Location: operationLocation(op.path, op.method),
+				Metadata: map[string]string{
+					"operation_id": op.op.OperationID,
+					"first_use":    first,
+				},
+			})
+			continue
 		}
+		firstSeen[op.op.OperationID] = endpoint
 	}
 
 	if missingCount > 0 || duplicateCount > 0 {
@@ -286,9 +282,12 @@ func (r *OperationIDRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
 		}
 
 		return core.RuleResult{
-			RuleID: r.ID(),
-			Passed: false,
-			Detail: detail,
+			RuleID:   r.ID(),
+			Passed:   false,
+			Detail:   detail,
+			Severity: "warning",
+			Category: "structure",
+			Findings: findings,
 		}
 	}
 
@@ -0,0 +1,419 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// HTTPSemanticsRules returns the rules checking that operations follow HTTP
+// semantics (RFC 9110): request bodies, status codes and headers that match
+// what the method and the path promise
+func HTTPSemanticsRules() []core.Rule {
+	return []core.Rule{
+		&RequestBodySemanticsRule{},
+		&CreatedResponseRule{},
+		&NoContentResponseRule{},
+		&AuthErrorResponseRule{},
+		&NotFoundResponseRule{},
+		&RateLimitResponseRule{},
+	}
+}
+
+// responseLocation builds the location of a response of an operation
+func responseLocation(path, method, code string) *core.RuleLocation {
+	location := operationLocation(path, method)
+	location.Path += ".responses." + code
+	return location
+}
+
+// RequestBodySemanticsRule checks that GET, HEAD and DELETE operations take no
+// request body: the body has no defined meaning for them, and proxies and
+// clients may drop it
+type RequestBodySemanticsRule struct{}
+
+func (r *RequestBodySemanticsRule) ID() string {
+	return "http-no-request-body"
+}
+
+func (r *RequestBodySemanticsRule) Description() string {
+	return "GET, HEAD and DELETE operations should not define a request body"
+}
+
+func (r *RequestBodySemanticsRule) AppliesTo(version string) bool {
+	return strings.HasPrefix(version, "3.")
+}
+
+func (r *RequestBodySemanticsRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
+	var findings []core.Finding
+	for _, op := range specOperations(ctx.Spec) {
+		if op.op.RequestBody == nil {
+			continue
+		}
+		switch op.method {
+		case "GET", "HEAD", "DELETE":
+			location := operationLocation(op.path, op.method)
+			location.Path += ".requestBody"
+			findings = append(findings, core.Finding{
+				Detail:   fmt.Sprintf("%s %s defines a request body", op.method, op.path),
+				Location: location,
+			})
+		}
+	}
+
+	return findingsResult(r.ID(), "warning", "http_semantics", findings,
+		"No GET, HEAD or DELETE operation defines a request body",
+		"operations define a request body their method gives no meaning",
+		&core.ActionableFix{
+			Title:       "Move Request Data Out of the Body",
+			Description: "Pass the data of GET, HEAD and DELETE requests as query or path parameters, or use POST for queries too complex for a URL",
+			Example: `get:
+  parameters:
+    - name: status
+      in: query
+      schema:
+        type: string`,
+			References: []string{"https://www.rfc-editor.org/rfc/rfc9110#section-9.3.1"},
+			SchemaRef:  "https://www.rfc-editor.org/rfc/rfc9110#section-9.3.1",
+		})
+}
+
+// CreatedResponseRule checks that POST operations creating a resource answer
+// 201 Created with a Location header. A POST to a collection path, one that
+// has item paths below it such as /users/{id}, is taken to create an item.
+// Operations that answer 202 Accepted create asynchronously and are skipped.
+type CreatedResponseRule struct{}
+
+func (r *CreatedResponseRule) ID() string {
+	return "http-post-created"
+}
+
+func (r *CreatedResponseRule) Description() string {
+	return "POST operations creating a resource should return 201 Created with a Location header"
+}
+
+func (r *CreatedResponseRule) AppliesTo(version string) bool {
+	return strings.HasPrefix(version, "3.")
+}
+
+func (r *CreatedResponseRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
+	var findings []core.Finding
+	for _, op := range specOperations(ctx.Spec) {
+		if op.method != "POST" || !isCollectionPath(ctx.Spec, op.path) {
+			continue
+		}
+		// Only an explicit 202 marks an asynchronous creation, a 2XX range also covers 201
+		if _, accepted := op.op.Responses["202"]; accepted {
+			continue
+		}
+
+		created, key, ok := statusResponse(op.op.Responses, "201")
+		if !ok {
+			findings = append(findings, core.Finding{
+				Detail:   fmt.Sprintf("POST %s creates a resource but has no 201 response", op.path),
+				Location: operationLocation(op.path, op.method),
+			})
+			continue
+		}
+		if created.Value != nil && !hasHeader(created.Value.Headers, "Location") {
+			findings = append(findings, core.Finding{
+				Detail:   fmt.Sprintf("POST %s %s response has no Location header", op.path, key),
+				Location: responseLocation(op.path, op.method, key),
+			})
+		}
+	}
+
+	return findingsResult(r.ID(), "warning", "http_semantics", findings,
+		"All POST operations creating a resource return 201 with a Location header",
+		"POST operations do not report the created resource",
+		&core.ActionableFix{
+			Title:       "Return 201 Created with a Location Header",
+			Description: "Answer a successful creation with 201 and point to the new resource with a Location header",
+			Example: `responses:
+  '201':
+    description: User created
+    headers:
+      Location:
+        description: URL of the created user
+        schema:
+          type: string
+          format: uri`,
+			References: []string{"https://www.rfc-editor.org/rfc/rfc9110#section-15.3.2"},
+			SchemaRef:  "https://www.rfc-editor.org/rfc/rfc9110#section-15.3.2",
+		})
+}
+
+// isCollectionPath reports whether items are addressed below a path, e.g.
+// /users when the spec also has /users/{id}
+func isCollectionPath(spec *openapi3.T, path string) bool {
+	if strings.HasSuffix(path, "}") {
+		return false
+	}
+	prefix := strings.TrimSuffix(path, "/") + "/{"
+	for other := range spec.Paths {
+		if strings.HasPrefix(other, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// NoContentResponseRule checks that 204 No Content responses define no content
+type NoContentResponseRule struct{}
+
+func (r *NoContentResponseRule) ID() string {
+	return "http-no-content-body"
+}
+
+func (r *NoContentResponseRule) Description() string {
+	return "204 No Content responses must not define a response body"
+}
+
+func (r *NoContentResponseRule) AppliesTo(version string) bool {
+	return strings.HasPrefix(version, "3.")
+}
+
+func (r *NoContentResponseRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
+	var findings []core.Finding
+	for _, op := range specOperations(ctx.Spec) {
+		response, ok := op.op.Responses["204"]
+		if !ok || response.Value == nil || len(response.Value.Content) == 0 {
+			continue
+		}
+		findings = append(findings, core.Finding{
+			Detail:   fmt.Sprintf("%s %s 204 response defines content", op.method, op.path),
+			Location: responseLocation(op.path, op.method, "204"),
+		})
+	}
+
+	return findingsResult(r.ID(), "warning", "http_semantics", findings,
+		"No 204 response defines content",
+		"204 No Content responses define content",
+		&core.ActionableFix{
+			Title:       "Remove the Content of 204 Responses",
+			Description: "A 204 response ends after its headers; return 200 when the response has a body",
+			Example: `responses:
+  '204':
+    description: User deleted`,
+			References: []string{"https://www.rfc-editor.org/rfc/rfc9110#section-15.3.5"},
+			SchemaRef:  "https://www.rfc-editor.org/rfc/rfc9110#section-15.3.5",
+		})
+}
+
+// AuthErrorResponseRule checks that secured operations document the 401 and
+// 403 responses clients get when authentication is missing or insufficient.
+// Operations whose security has an empty requirement allow anonymous access
+// and are skipped.
+type AuthErrorResponseRule struct{}
+
+func (r *AuthErrorResponseRule) ID() string {
+	return "http-auth-responses"
+}
+
+func (r *AuthErrorResponseRule) Description() string {
+	return "Secured operations should define 401 and 403 responses"
+}
+
+func (r *AuthErrorResponseRule) AppliesTo(version string) bool {
+	return strings.HasPrefix(version, "3.")
+}
+
+func (r *AuthErrorResponseRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
+	var findings []core.Finding
+	for _, op := range specOperations(ctx.Spec) {
+		if !isSecured(ctx.Spec, op.op) {
+			continue
+		}
+
+		var missing []string
+		for _, code := range []string{"401", "403"} {
+			if !declaresStatus(op.op.Responses, code) {
+				missing = append(missing, code)
+			}
+		}
+		if len(missing) > 0 {
+			findings = append(findings, core.Finding{
+				Detail:   fmt.Sprintf("%s %s is secured but has no %s response", op.method, op.path, strings.Join(missing, " or ")),
+				Location: operationLocation(op.path, op.method),
+				Metadata: map[string]string{
+					"missing_responses": strings.Join(missing, ","),
+				},
+			})
+		}
+	}
+
+	return findingsResult(r.ID(), "warning", "http_semantics", findings,
+		"All secured operations define 401 and 403 responses",
+		"secured operations do not document authentication failures",
+		&core.ActionableFix{
+			Title:       "Document Authentication Failures",
+			Description: "Define 401 for missing or invalid credentials and 403 for credentials without access",
+			Example: `responses:
+  '401':
+    $ref: '#/components/responses/Unauthorized'
+  '403':
+    $ref: '#/components/responses/Forbidden'`,
+			References: []string{
+				"https://www.rfc-editor.org/rfc/rfc9110#section-15.5.2",
+				"https://www.rfc-editor.org/rfc/rfc9110#section-15.5.4",
+			},
+			SchemaRef: "https://spec.openapis.org/oas/v3.1.0#security-requirement-object",
+		})
+}
+
+// isSecured reports whether an operation requires authentication, through its
+// own security or the security of the spec
+func isSecured(spec *openapi3.T, operation *openapi3.Operation) bool {
+	requirements := spec.Security
+	if operation.Security != nil {
+		requirements = *operation.Security
+	}
+	if len(requirements) == 0 {
+		return false
+	}
+	for _, requirement := range requirements {
+		if len(requirement) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// NotFoundResponseRule checks that operations on paths with path parameters
+// document the 404 response returned when the addressed resource does not exist
+type NotFoundResponseRule struct{}
+
+func (r *NotFoundResponseRule) ID() string {
+	return "http-not-found-response"
+}
+
+func (r *NotFoundResponseRule) Description() string {
+	return "Operations on paths with path parameters should define a 404 response"
+}
+
+func (r *NotFoundResponseRule) AppliesTo(version string) bool {
+	return strings.HasPrefix(version, "3.")
+}
+
+func (r *NotFoundResponseRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
+	var findings []core.Finding
+	for _, op := range specOperations(ctx.Spec) {
+		if !strings.Contains(op.path, "{") || declaresStatus(op.op.Responses, "404") {
+			continue
+		}
+		findings = append(findings, core.Finding{
+			Detail:   fmt.Sprintf("%s %s has path parameters but no 404 response", op.method, op.path),
+			Location: operationLocation(op.path, op.method),
+		})
+	}
+
+	return findingsResult(r.ID(), "warning", "http_semantics", findings,
+		"All operations on item paths define a 404 response",
+		"operations on item paths do not document 404",
+		&core.ActionableFix{
+			Title:       "Add a 404 Response",
+			Description: "Document the response returned when no resource matches the path parameters",
+			Example: `responses:
+  '404':
+    description: User not found`,
+			References: []string{"https://www.rfc-editor.org/rfc/rfc9110#section-15.5.5"},
+			SchemaRef:  "https://www.rfc-editor.org/rfc/rfc9110#section-15.5.5",
+		})
+}
+
+// RateLimitResponseRule checks that operations announcing rate limits through
+// response headers also document the 429 response sent once the limit is hit
+type RateLimitResponseRule struct{}
+
+func (r *RateLimitResponseRule) ID() string {
+	return "http-rate-limit-response"
+}
+
+func (r *RateLimitResponseRule) Description() string {
+	return "Operations declaring rate-limit headers should define a 429 response"
+}
+
+func (r *RateLimitResponseRule) AppliesTo(version string) bool {
+	return strings.HasPrefix(version, "3.")
+}
+
+func (r *RateLimitResponseRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
+	var findings []core.Finding
+	for _, op := range specOperations(ctx.Spec) {
+		headers := rateLimitHeaders(op.op)
+		if len(headers) == 0 || declaresStatus(op.op.Responses, "429") {
+			continue
+		}
+		findings = append(findings, core.Finding{
+			Detail:   fmt.Sprintf("%s %s declares rate-limit headers but no 429 response", op.method, op.path),
+			Location: operationLocation(op.path, op.method),
+			Metadata: map[string]string{
+				"headers": strings.Join(headers, ","),
+			},
+		})
+	}
+
+	return findingsResult(r.ID(), "warning", "http_semantics", findings,
+		"All rate-limited operations define a 429 response",
+		"rate-limited operations do not document 429",
+		&core.ActionableFix{
+			Title:       "Add a 429 Response",
+			Description: "Document the response returned once the rate limit is exceeded, with a Retry-After header",
+			Example: `responses:
+  '429':
+    description: Too many requests
+    headers:
+      Retry-After:
+        schema:
+          type: integer`,
+			References: []string{"https://www.rfc-editor.org/rfc/rfc6585#section-4"},
+			SchemaRef:  "https://www.rfc-editor.org/rfc/rfc6585#section-4",
+		})
+}
+
+// rateLimitHeaders returns the rate-limit headers declared by the responses of an operation
+func rateLimitHeaders(operation *openapi3.Operation) []string {
+	found := map[string]bool{}
+	for _, response := range operation.Responses {
+		if response == nil || response.Value == nil {
+			continue
+		}
+		for name := range response.Value.Headers {
+			if isRateLimitHeader(name) {
+				found[name] = true
+			}
+		}
+	}
+
+	headers := make([]string, 0, len(found))
+	for name := range found {
+		headers = append(headers, name)
+	}
+	sort.Strings(headers)
+	return headers
+}
+
+// isRateLimitHeader recognizes the RateLimit headers of the IETF draft and
+// their common X- variants. Retry-After is left out: 503 and redirects use it too.
+func isRateLimitHeader(name string) bool {
+	name = strings.ToLower(name)
+	for _, prefix := range []string{"ratelimit", "x-ratelimit", "x-rate-limit"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasHeader reports whether a response declares a header, ignoring case
+func hasHeader(headers openapi3.Headers, name string) bool {
+	for header := range headers {
+		if strings.EqualFold(header, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,775 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Default naming styles, see namingStyles for the supported ones
+const (
+	DefaultPathStyle        = "kebab-case"
+	DefaultPropertyStyle    = "auto" // The style most property names already follow
+	DefaultOperationIDStyle = "camelCase"
+	DefaultSchemaStyle      = "PascalCase"
+	DefaultHeaderStyle      = "Train-Case"
+)
+
+// NamingRules returns the rules checking that paths, properties, operation
+// IDs, schemas and headers each follow one naming style throughout the spec.
+// The style of every rule can be changed with its style option.
+func NamingRules() []core.Rule {
+	return []core.Rule{
+		&PathNamingRule{},
+		&PropertyNamingRule{},
+		&OperationIDNamingRule{},
+		&SchemaNamingRule{},
+		&HeaderNamingRule{},
+	}
+}
+
+// namingStyle is a naming convention, e.g. camelCase
+type namingStyle struct {
+	name    string
+	pattern *regexp.Regexp
+	join    func(words []string) string // Builds a name in the style from lower-case words
+}
+
+// namingStyles are the supported styles. The order breaks ties when the
+// style of property names is detected.
+var namingStyles = []namingStyle{
+	{"camelCase", regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`), func(words []string) string {
+		return words[0] + strings.Join(capitalizeWords(words[1:]), "")
+	}},
+	{"snake_case", regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`), func(words []string) string {
+		return strings.Join(words, "_")
+	}},
+	{"PascalCase", regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*$`), func(words []string) string {
+		return strings.Join(capitalizeWords(words), "")
+	}},
+	{"kebab-case", regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`), func(words []string) string {
+		return strings.Join(words, "-")
+	}},
+	{"SCREAMING_SNAKE_CASE", regexp.MustCompile(`^[A-Z][A-Z0-9]*(_[A-Z0-9]+)*$`), func(words []string) string {
+		return strings.ToUpper(strings.Join(words, "_"))
+	}},
+	{"Train-Case", regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*(-[A-Z0-9][a-zA-Z0-9]*)*$`), func(words []string) string {
+		return strings.Join(capitalizeWords(words), "-")
+	}},
+}
+
+// lookupNamingStyle returns the style with the given name
+func lookupNamingStyle(name string) (namingStyle, bool) {
+	for _, style := range namingStyles {
+		if style.name == name {
+			return style, true
+		}
+	}
+	return namingStyle{}, false
+}
+
+// namingStyleOrDefault returns the configured style, or the fallback when none is set
+func namingStyleOrDefault(name, fallback string) namingStyle {
+	if style, ok := lookupNamingStyle(name); ok {
+		return style
+	}
+	style, _ := lookupNamingStyle(fallback)
+	return style
+}
+
+// styleOption reads the style option of a naming rule. auto is accepted
+// only when the rule can detect the style itself.
+func styleOption(options core.RuleOptions, fallback string, allowAuto bool) (string, error) {
+	name, err := options.String("style", fallback)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := lookupNamingStyle(name); ok || allowAuto && name == "auto" {
+		return name, nil
+	}
+
+	supported := make([]string, 0, len(namingStyles)+1)
+	for _, style := range namingStyles {
+		supported = append(supported, style.name)
+	}
+	if allowAuto {
+		supported = append(supported, "auto")
+	}
+	return "", fmt.Errorf("unknown style %q, expected one of %s", name, strings.Join(supported, ", "))
+}
+
+func (s namingStyle) matches(name string) bool {
+	return s.pattern.MatchString(name)
+}
+
+// suggest renames a name to the style, empty when it cannot
+func (s namingStyle) suggest(name string) string {
+	words := splitWords(name)
+	if len(words) == 0 {
+		return ""
+	}
+	suggestion := s.join(words)
+	if suggestion == name || !s.matches(suggestion) {
+		return ""
+	}
+	return suggestion
+}
+
+func capitalizeWords(words []string) []string {
+	capitalized := make([]string, len(words))
+	for i, word := range words {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		capitalized[i] = string(runes)
+	}
+	return capitalized
+}
+
+// namedItem is a name declared in the spec
+type namedItem struct {
+	name     string
+	what     string // The name and what it names, for finding details, e.g. Schema Pet property owner.pet_id
+	location *core.RuleLocation
+}
+
+// styleFindings reports every name that does not follow the style
+func styleFindings(items []namedItem, style namingStyle) []core.Finding {
+	var findings []core.Finding
+	for _, item := range items {
+		if style.matches(item.name) {
+			continue
+		}
+
+		detail := fmt.Sprintf("%s is not %s", item.what, style.name)
+		metadata := map[string]string{"name": item.name, "style": style.name}
+		if suggestion := style.suggest(item.name); suggestion != "" {
+			detail += ", expected " + suggestion
+			metadata["suggestion"] = suggestion
+		}
+		findings = append(findings, core.Finding{
+			Detail:   detail,
+			Location: item.location,
+			Metadata: metadata,
+		})
+	}
+	return findings
+}
+
+// PathNamingRule checks the static segments of paths against a style, and
+// that collections addressed by a path parameter are named in the plural,
+// e.g. /users/{id} rather than /user/{id}
+type PathNamingRule struct {
+	Style string // DefaultPathStyle when empty
+	// SingularCollections turns the plural check off
+	SingularCollections bool
+}
+
+func (r *PathNamingRule) ID() string {
+	return "naming-path-segments"
+}
+
+func (r *PathNamingRule) Description() string {
+	return "Path segments should follow one naming style and name collections in the plural"
+}
+
+func (r *PathNamingRule) AppliesTo(version string) bool {
+	return strings.HasPrefix(version, "3.")
+}
+
+// Configure reads the style and plural_collections options
+func (r *PathNamingRule) Configure(options core.RuleOptions) error {
+	if err := options.CheckKeys("style", "plural_collections"); err != nil {
+		return err
+	}
+
+	style, err := styleOption(options, DefaultPathStyle, false)
+	if err != nil {
+		return err
+	}
+	plural, err := options.Bool("plural_collections", true)
+	if err != nil {
+		return err
+	}
+
+	r.Style = style
+	r.SingularCollections = !plural
+	return nil
+}
+
+func (r *PathNamingRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
+	style := namingStyleOrDefault(r.Style, DefaultPathStyle)
+
+	var findings []core.Finding
+	for _, path := range sortedKeys(ctx.Spec.Paths) {
+		segments := strings.Split(strings.Trim(path, "/"), "/")
+		for i, segment := range segments {
+			if segment == "" || strings.Contains(segment, "{") {
+				continue
+			}
+			collection := !r.SingularCollections && i+1 < len(segments) && isPathParameter(segments[i+1])
+			if finding, ok := r.checkSegment(path, i, segment, style, collection); ok {
+				findings = append(findings, finding)
+			}
+		}
+	}
+
+	return findingsResult(r.ID(), "info", "consistency", findings,
+		fmt.Sprintf("Path segments are %s", style.name),
+		"path naming issues",
+		&core.ActionableFix{
+			Title:       "Name Path Segments Consistently",
+			Description: fmt.Sprintf("Write path segments in %s and name collections in the plural", style.name),
+			Example: `paths:
+  /user-profiles/{profileId}:   # not /userProfile/{profileId}
+    get:
+      operationId: getUserProfile`,
+			References: []string{"https://restfulapi.net/resource-naming/"},
+			SchemaRef:  "https://spec.openapis.org/oas/v3.1.0#paths-object",
+		})
+}
+
+// checkSegment reports a path segment that does not follow the style or, when
+// it names a collection, is singular. Both problems of a segment are reported
+// in one finding with one suggestion. Every segment has its own location below
+// its path, e.g. $.paths./users/{id}.segments[0], which resolves to the path.
+func (r *PathNamingRule) checkSegment(path string, index int, segment string, style namingStyle, collection bool) (core.Finding, bool) {
+	// File extensions (openapi.json) and custom methods (/files:upload) are checked word by word
+	var parts, separators []string
+	start := 0
+	for i, c := range segment {
+		if c == '.' || c == ':' {
+			parts = append(parts, segment[start:i])
+			separators = append(separators, string(c))
+			start = i + 1
+		}
+	}
+	parts = append(parts, segment[start:])
+
+	styled := true
+	renamed := append([]string(nil), parts...)
+	for i, part := range parts {
+		if part == "" || style.matches(part) {
+			continue
+		}
+		styled = false
+		if suggestion := style.suggest(part); suggestion != "" {
+			renamed[i] = suggestion
+		}
+	}
+
+	// The collection is named by the last word of the segment
+	singular := false
+	if collection {
+		words := splitWords(parts[len(parts)-1])
+		if len(words) > 0 {
+			if plural, ok := pluralWord(words[len(words)-1]); ok {
+				singular = true
+				words[len(words)-1] = plural
+				renamed[len(renamed)-1] = style.join(words)
+			}
+		}
+	}
+
+	var detail string
+	switch {
+	case !styled && singular:
+		detail = fmt.Sprintf("Path %s segment %s is not %s and names a collection in the singular", path, segment, style.name)
+	case !styled:
+		detail = fmt.Sprintf("Path %s segment %s is not %s", path, segment, style.name)
+	case singular:
+		detail = fmt.Sprintf("Path %s names the collection %s in the singular", path, segment)
+	default:
+		return core.Finding{}, false
+	}
+
+	suggestion := renamed[0]
+	for i, separator := range separators {
+		suggestion += separator + renamed[i+1]
+	}
+	metadata := map[string]string{"name": segment, "style": style.name}
+	if suggestion != segment {
+		detail += ", expected " + suggestion
+		metadata["suggestion"] = suggestion
+	}
+	return core.Finding{
+		Detail: detail,
+		Location: &core.RuleLocation{
+			Path:        fmt.Sprintf("$.paths.%s.segments.%d", path, index),
+			Component:   "path",
+			Endpoint:    path,
+			SpecSection: "paths",
+		},
+		Metadata: metadata,
+	}, true
+}
+
+// isPathParameter reports whether a path segment is a single parameter, e.g. {id}
+func isPathParameter(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") && strings.Count(segment, "{") == 1
+}
+
+// uncountableNouns are plural, or have no plural form, without ending in s
+var uncountableNouns = map[string]bool{
+	"data":        true,
+	"metadata":    true,
+	"media":       true,
+	"people":      true,
+	"children":    true,
+	"information": true,
+	"feedback":    true,
+	"equipment":   true,
+	"software":    true,
+	"staff":       true,
+}
+
+// pluralWord returns the plural of a lowercase word in the singular; ok is
+// false when the word is already plural or cannot be told apart, e.g. v1
+func pluralWord(word string) (string, bool) {
+	if word == "" || strings.IndexFunc(word, unicode.IsDigit) >= 0 || uncountableNouns[word] {
+		return "", false
+	}
+	if strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") {
+		return "", false
+	}
+
+	switch {
+	case strings.HasSuffix(word, "ss"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		return word + "es", true
+	case strings.HasSuffix(word, "y") && len(word) > 1 && !strings.ContainsRune("aeiou", rune(word[len(word)-2])):
+		return word[:len(word)-1] + "ies", true
+	}
+	return word + "s", true
+}
+
+// PropertyNamingRule checks that schema property names follow one style. By
+// default the style is the one most property names already follow, so a
+// snake_case API is not told to switch to camelCase. Names starting with a
+// symbol, such as _links, @id or $schema, follow their own conventions and are
+// left out.
+type PropertyNamingRule struct {
+	Style string // DefaultPropertyStyle when empty
+}
+
+func (r *PropertyNamingRule) ID() string {
+	return "naming-property-case"
+}
+
+func (r *PropertyNamingRule) Description() string {
+	return "Schema property names should follow one naming style"
+}
+
+func (r *PropertyNamingRule) AppliesTo(version string) bool {
+	return strings.HasPrefix(version, "3.")
+}
+
+// Configure reads the style option
+func (r *PropertyNamingRule) Configure(options core.RuleOptions) error {
+	if err := options.CheckKeys("style"); err != nil {
+		return err
+	}
+
+	style, err := styleOption(options, DefaultPropertyStyle, true)
+	if err != nil {
+		return err
+	}
+	r.Style = style
+	return nil
+}
+
+func (r *PropertyNamingRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
+	collector := &propertyCollector{visited: make(map[*openapi3.Schema]bool)}
+	collector.collectSpec(ctx.Spec)
+	items := collector.items
+
+	metadata := map[string]string{"properties": fmt.Sprintf("%d", len(items))}
+	style, ok := lookupNamingStyle(r.Style)
+	if !ok { // auto
+		style = detectNamingStyle(items)
+		metadata["detected"] = "true"
+	}
+	metadata["style"] = style.name
+
+	result := findingsResult(r.ID(), "info", "consistency", styleFindings(items, style),
+		fmt.Sprintf("Property names are %s", style.name),
+		"property names break the "+style.name+" convention",
+		&core.ActionableFix{
+			Title:       "Name Properties Consistently",
+			Description: fmt.Sprintf("Rename the properties to %s, the convention of this API", style.name),
+			Example: `Pet:
+  type: object
+  properties:
+    petId:         # not pet_id next to ownerName
+      type: string
+    ownerName:
+      type: string`,
+			SchemaRef: "https://spec.openapis.org/oas/v3.1.0#schema-object",
+		})
+	result.Metadata = metadata
+	return result
+}
+
+// detectNamingStyle returns the style most names follow. One-word names
+// such as id follow several styles and count for each of them.
+func detectNamingStyle(items []namedItem) namingStyle {
+	best, bestCount := namingStyles[0], -1
+	for _, style := range namingStyles {
+		count := 0
+		for _, item := range items {
+			if style.matches(item.name) {
+				count++
+			}
+		}
+		if count > bestCount {
+			best, bestCount = style, count
+		}
+	}
+	return best
+}
+
+// propertyCollector collects the property names of the component schemas and
+// of the schemas declared inline in request bodies and responses
+type propertyCollector struct {
+	visited map[*openapi3.Schema]bool
+	items   []namedItem
+}
+
+func (c *propertyCollector) collectSpec(spec *openapi3.T) {
+	if spec == nil {
+		return
+	}
+
+	if components := spec.Components; components != nil {
+		for _, name := range sortedKeys(components.Schemas) {
+			c.collectSchema(components.Schemas[name], "$.components.schemas."+name, "Schema "+name, "")
+		}
+		for _, name := range sortedKeys(components.RequestBodies) {
+			if body := components.RequestBodies[name]; body != nil && body.Ref == "" && body.Value != nil {
+				c.collectContent(body.Value.Content, "$.components.requestBodies."+name, "Request body "+name)
+			}
+		}
+		for _, name := range sortedKeys(components.Responses) {
+			if response := components.Responses[name]; response != nil && response.Ref == "" && response.Value != nil {
+				c.collectContent(response.Value.Content, "$.components.responses."+name, "Response "+name)
+			}
+		}
+	}
+
+	for _, op := range specOperations(spec) {
+		jsonPath := operationLocation(op.path, op.method).Path
+		owner := op.method + " " + op.path
+
+		if body := op.op.RequestBody; body != nil && body.Ref == "" && body.Value != nil {
+			c.collectContent(body.Value.Content, jsonPath+".requestBody", owner+" request body")
+		}
+		for _, code := range sortedKeys(op.op.Responses) {
+			if response := op.op.Responses[code]; response != nil && response.Ref == "" && response.Value != nil {
+				c.collectContent(response.Value.Content, jsonPath+".responses."+code, owner+" response "+code)
+			}
+		}
+	}
+}
+
+func (c *propertyCollector) collectContent(content openapi3.Content, path, owner string) {
+	for _, mediaType := range sortedKeys(content) {
+		if media := content[mediaType]; media != nil {
+			c.collectSchema(media.Schema, path+".content."+mediaType+".schema", owner, "")
+		}
+	}
+}
+
+// collectSchema collects the property names of an inline schema and the
+// schemas nested in it. field is the dotted path of the schema within owner.
+func (c *propertyCollector) collectSchema(ref *openapi3.SchemaRef, path, owner, field string) {
+	if ref == nil || ref.Ref != "" || ref.Value == nil || c.visited[ref.Value] {
+		return
+	}
+	schema := ref.Value
+	c.visited[schema] = true
+
+	for _, name := range sortedKeys(schema.Properties) {
+		propertyPath := path + ".properties." + name
+		propertyField := name
+		if field != "" {
+			propertyField = field + "." + name
+		}
+
+		if first := []rune(name); len(first) > 0 && unicode.IsLetter(first[0]) {
+			c.items = append(c.items, namedItem{
+				name:     name,
+				what:     owner + " property " + propertyField,
+				location: &core.RuleLocation{Path: propertyPath, Component: owner},
+			})
+		}
+		c.collectSchema(schema.Properties[name], propertyPath, owner, propertyField)
+	}
+
+	c.collectSchema(schema.Items, path+".items", owner, field)
+	c.collectSchema(schema.AdditionalProperties.Schema, path+".additionalProperties", owner, field)
+	for i, sub := range schema.AllOf {
+		c.collectSchema(sub, fmt.Sprintf("%s.allOf.%d", path, i), owner, field)
+	}
+	for i, sub := range schema.OneOf {
+		c.collectSchema(sub, fmt.Sprintf("%s.oneOf.%d", path, i), owner, field)
+	}
+	for i, sub := range schema.AnyOf {
+		c.collectSchema(sub, fmt.Sprintf("%s.anyOf.%d", path, i), owner, field)
+	}
+}
+
+// OperationIDNamingRule checks that operation IDs follow one style. Code
+// generators turn them into method names.
+type OperationIDNamingRule struct {
+	Style string // DefaultOperationIDStyle when empty
+}
+
+func (r *OperationIDNamingRule) ID() string {
+	return "naming-operation-id"
+}
+
+func (r *OperationIDNamingRule) Description() string {
+	return "Operation IDs should follow one naming style"
+}
+
+func (r *OperationIDNamingRule) AppliesTo(version string) bool {
+	return strings.HasPrefix(version, "3.")
+}
+
+// Configure reads the style option
+func (r *OperationIDNamingRule) Configure(options core.RuleOptions) error {
+	if err := options.CheckKeys("style"); err != nil {
+		return err
+	}
+
+	style, err := styleOption(options, DefaultOperationIDStyle, false)
+	if err != nil {
+		return err
+	}
+	r.Style = style
+	return nil
+}
+
+func (r *OperationIDNamingRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
+	style := namingStyleOrDefault(r.Style, DefaultOperationIDStyle)
+
+	var items []namedItem
+	for _, op := range specOperations(ctx.Spec) {
+		if op.op.OperationID == "" {
+			continue // Missing IDs are reported by operation-operationId-unique
+		}
+		location := operationLocation(op.path, op.method)
+		location.Path += ".operationId"
+		items = append(items, namedItem{
+			name:     op.op.OperationID,
+			what:     fmt.Sprintf("%s %s operationId %s", op.method, op.path, op.op.OperationID),
+			location: location,
+		})
+	}
+
+	return findingsResult(r.ID(), "info", "consistency", styleFindings(items, style),
+		fmt.Sprintf("Operation IDs are %s", style.name),
+		"operation IDs break the "+style.name+" convention",
+		&core.ActionableFix{
+			Title:       "Name Operation IDs Consistently",
+			Description: fmt.Sprintf("Rename the operation IDs to %s; generated clients use them as method names", style.name),
+			Example: `paths:
+  /users/{userId}:
+    get:
+      operationId: getUser   # not get_user or GetUser`,
+			SchemaRef: "https://spec.openapis.org/oas/v3.1.0#operation-object",
+		})
+}
+
+// SchemaNamingRule checks that component schema names follow one style.
+// Code generators turn them into type names.
+type SchemaNamingRule struct {
+	Style string // DefaultSchemaStyle when empty
+}
+
+func (r *SchemaNamingRule) ID() string {
+	return "naming-schema-names"
+}
+
+func (r *SchemaNamingRule) Description() string {
+	return "Component schema names should follow one naming style"
+}
+
+func (r *SchemaNamingRule) AppliesTo(version string) bool {
+	return strings.HasPrefix(version, "3.")
+}
+
+// Configure reads the style option
+func (r *SchemaNamingRule) Configure(options core.RuleOptions) error {
+	if err := options.CheckKeys("style"); err != nil {
+		return err
+	}
+
+	style, err := styleOption(options, DefaultSchemaStyle, false)
+	if err != nil {
+		return err
+	}
+	r.Style = style
+	return nil
+}
+
+func (r *SchemaNamingRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
+	style := namingStyleOrDefault(r.Style, DefaultSchemaStyle)
+
+	var items []namedItem
+	if ctx.Spec.Components != nil {
+		for _, name := range sortedKeys(ctx.Spec.Components.Schemas) {
+			items = append(items, namedItem{
+				name: name,
+				what: "Schema " + name,
+				location: &core.RuleLocation{
+					Path:        "$.components.schemas." + name,
+					Component:   name,
+					SpecSection: "components",
+				},
+			})
+		}
+	}
+
+	return findingsResult(r.ID(), "info", "consistency", styleFindings(items, style),
+		fmt.Sprintf("Schema names are %s", style.name),
+		"schema names break the "+style.name+" convention",
+		&core.ActionableFix{
+			Title:       "Name Schemas Consistently",
+			Description: fmt.Sprintf("Rename the schemas to %s and update the references to them", style.name),
+			Example: `components:
+  schemas:
+    UserProfile:   # not user_profile or userProfile
+      type: object`,
+			SchemaRef: "https://spec.openapis.org/oas/v3.1.0#components-object",
+		})
+}
+
+// HeaderNamingRule checks that the names of header parameters and response
+// headers follow one style. Header names are case-insensitive on the wire,
+// but generated code and documentation show them as written.
+type HeaderNamingRule struct {
+	Style string // DefaultHeaderStyle when empty
+}
+
+func (r *HeaderNamingRule) ID() string {
+	return "naming-header-names"
+}
+
+func (r *HeaderNamingRule) Description() string {
+	return "Header names should follow one naming style"
+}
+
+func (r *HeaderNamingRule) AppliesTo(version string) bool {
+	return strings.HasPrefix(version, "3.")
+}
+
+// Configure reads the style option
+func (r *HeaderNamingRule) Configure(options core.RuleOptions) error {
+	if err := options.CheckKeys("style"); err != nil {
+		return err
+	}
+
+	style, err := styleOption(options, DefaultHeaderStyle, false)
+	if err != nil {
+		return err
+	}
+	r.Style = style
+	return nil
+}
+
+func (r *HeaderNamingRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
+	style := namingStyleOrDefault(r.Style, DefaultHeaderStyle)
+
+	return findingsResult(r.ID(), "info", "consistency", styleFindings(headerNames(ctx.Spec), style),
+		fmt.Sprintf("Header names are %s", style.name),
+		"header names break the "+style.name+" convention",
+		&core.ActionableFix{
+			Title:       "Name Headers Consistently",
+			Description: fmt.Sprintf("Write header names in %s", style.name),
+			Example: `parameters:
+  - name: X-Request-Id   # not x-request-id or X_Request_Id
+    in: header
+    schema:
+      type: string`,
+			References: []string{"https://www.rfc-editor.org/rfc/rfc9110#section-5.1"},
+			SchemaRef:  "https://spec.openapis.org/oas/v3.1.0#header-object",
+		})
+}
+
+// headerNames returns the header parameters and response headers of the
+// spec, where they are defined
+func headerNames(spec *openapi3.T) []namedItem {
+	var items []namedItem
+	addParameters := func(parameters openapi3.Parameters, path, owner string) {
+		for i, parameter := range parameters {
+			if parameter == nil || parameter.Ref != "" || parameter.Value == nil || parameter.Value.In != openapi3.ParameterInHeader {
+				continue
+			}
+			items = append(items, namedItem{
+				name:     parameter.Value.Name,
+				what:     owner + " header " + parameter.Value.Name,
+				location: &core.RuleLocation{Path: fmt.Sprintf("%s.parameters.%d", path, i), Component: owner},
+			})
+		}
+	}
+	addHeaders := func(headers openapi3.Headers, path, owner string) {
+		for _, name := range sortedKeys(headers) {
+			items = append(items, namedItem{
+				name:     name,
+				what:     owner + " header " + name,
+				location: &core.RuleLocation{Path: path + ".headers." + name, Component: owner},
+			})
+		}
+	}
+
+	if spec == nil {
+		return nil
+	}
+	if components := spec.Components; components != nil {
+		for _, name := range sortedKeys(components.Headers) {
+			items = append(items, namedItem{
+				name:     name,
+				what:     "Header " + name,
+				location: &core.RuleLocation{Path: "$.components.headers." + name, Component: name, SpecSection: "components"},
+			})
+		}
+		for _, name := range sortedKeys(components.Parameters) {
+			parameter := components.Parameters[name]
+			if parameter == nil || parameter.Ref != "" || parameter.Value == nil || parameter.Value.In != openapi3.ParameterInHeader {
+				continue
+			}
+			items = append(items, namedItem{
+				name:     parameter.Value.Name,
+				what:     "Parameter " + name + " header " + parameter.Value.Name,
+				location: &core.RuleLocation{Path: "$.components.parameters." + name, Component: name, SpecSection: "components"},
+			})
+		}
+		for _, name := range sortedKeys(components.Responses) {
+			if response := components.Responses[name]; response != nil && response.Ref == "" && response.Value != nil {
+				addHeaders(response.Value.Headers, "$.components.responses."+name, "Response "+name)
+			}
+		}
+	}
+
+	for _, path := range sortedKeys(spec.Paths) {
+		if item := spec.Paths[path]; item != nil {
+			addParameters(item.Parameters, "$.paths."+path, path)
+		}
+	}
+	for _, op := range specOperations(spec) {
+		jsonPath := operationLocation(op.path, op.method).Path
+		owner := op.method + " " + op.path
+
+		addParameters(op.op.Parameters, jsonPath, owner)
+		for _, code := range sortedKeys(op.op.Responses) {
+			if response := op.op.Responses[code]; response != nil && response.Ref == "" && response.Value != nil {
+				addHeaders(response.Value.Headers, jsonPath+".responses."+code, owner+" response "+code)
+			}
+		}
+	}
+	return items
+}
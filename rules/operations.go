@@ -0,0 +1,128 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// specOperation is a single operation together with the path and method it is declared under
+type specOperation struct {
+	path   string
+	method string
+	op     *openapi3.Operation
+}
+
+// specOperations returns all operations of the spec in a stable order (sorted by path, then method)
+func specOperations(spec *openapi3.T) []specOperation {
+	if spec == nil || len(spec.Paths) == 0 {
+		return nil
+	}
+
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var operations []specOperation
+	for _, path := range paths {
+		pathItem := spec.Paths[path]
+		if pathItem == nil {
+			continue
+		}
+
+		candidates := []struct {
+			method string
+			op     *openapi3.Operation
+		}{
+			{"GET", pathItem.Get},
+			{"POST", pathItem.Post},
+			{"PUT", pathItem.Put},
+			{"DELETE", pathItem.Delete},
+			{"PATCH", pathItem.Patch},
+			{"HEAD", pathItem.Head},
+			{"OPTIONS", pathItem.Options},
+		}
+
+		for _, candidate := range candidates {
+			if candidate.op == nil {
+				continue
+			}
+			operations = append(operations, specOperation{
+				path:   path,
+				method: candidate.method,
+				op:     candidate.op,
+			})
+		}
+	}
+
+	return operations
+}
+
+// operationLocation builds the location of an operation for per-finding reporting
+func operationLocation(path, method string) *core.RuleLocation {
+	return &core.RuleLocation{
+		Path:        fmt.Sprintf("$.paths.%s.%s", path, strings.ToLower(method)),
+		Component:   "operation",
+		Method:      method,
+		Endpoint:    path,
+		SpecSection: "paths",
+	}
+}
+
+// findingsResult builds the result of a rule from its findings: passed when
+// there are none, otherwise failed with the first findings in the detail
+func findingsResult(ruleID, severity, category string, findings []core.Finding, passed, failed string, fix *core.ActionableFix) core.RuleResult {
+	if len(findings) == 0 {
+		return core.RuleResult{
+			RuleID: ruleID,
+			Passed: true,
+			Detail: passed,
+		}
+	}
+
+	issues := make([]string, 0, min(3, len(findings)))
+	for _, finding := range findings[:min(3, len(findings))] {
+		issues = append(issues, finding.Detail)
+	}
+
+	return core.RuleResult{
+		RuleID:     ruleID,
+		Passed:     false,
+		Detail:     fmt.Sprintf("%d %s: %s", len(findings), failed, strings.Join(issues, "; ")),
+		Severity:   severity,
+		Category:   category,
+		Suggestion: fix,
+		Findings:   findings,
+	}
+}
+
+// statusResponse returns the response an operation declares for a status code,
+// either under the code itself or under its range (e.g. 4XX for 404), along
+// with the key it is declared under
+func statusResponse(responses openapi3.Responses, code string) (*openapi3.ResponseRef, string, bool) {
+	if response, ok := responses[code]; ok {
+		return response, code, true
+	}
+	for key, response := range responses {
+		if len(key) == 3 && key[0] == code[0] && strings.EqualFold(key[1:], "XX") {
+			return response, key, true
+		}
+	}
+	return nil, "", false
+}
+
+// declaresStatus reports whether an operation documents a status code: under
+// the code itself, its range, or the default response that covers every code
+// not declared explicitly
+func declaresStatus(responses openapi3.Responses, code string) bool {
+	if _, _, ok := statusResponse(responses, code); ok {
+		return true
+	}
+	_, ok := responses["default"]
+	return ok
+}
@@ -0,0 +1,430 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// componentSchemaPrefix is the reference prefix of the schemas in components
+const componentSchemaPrefix = "#/components/schemas/"
+
+// maxReportedCycles bounds the cycles reported for a spec: heavily
+// interlinked specs can have an exponential number of distinct cycles
+const maxReportedCycles = 50
+
+// CircularSchemaReferenceRule detects cycles of $refs between component
+// schemas. A recursive schema whose cycle passes through an optional property,
+// a nullable schema, an array or a choice between alternatives can terminate
+// and is reported for information only. A cycle made only of required
+// references has no finite instance, so no client or server can build a valid
+// payload, and fails the rule.
+type CircularSchemaReferenceRule struct{}
+
+func (r *CircularSchemaReferenceRule) ID() string {
+	return "circular-schema-references"
+}
+
+func (r *CircularSchemaReferenceRule) Description() string {
+	return "Schema references must not form cycles that cannot be instantiated"
+}
+
+func (r *CircularSchemaReferenceRule) AppliesTo(version string) bool {
+	return strings.HasPrefix(version, "3.")
+}
+
+// schemaEdge is a reference from one component schema to another
+type schemaEdge struct {
+	source   string
+	target   string
+	path     string // JSON path of the reference
+	via      string // Location of the reference within the schema, e.g. properties.owner
+	optional bool   // The reference can be left out of an instance
+}
+
+// schemaCycle is a cycle of references, starting and ending at the same schema
+type schemaCycle []schemaEdge
+
+func (r *CircularSchemaReferenceRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
+	graph := schemaReferenceGraph(ctx.Spec)
+	cycles, truncated := findSchemaCycles(graph)
+
+	if len(cycles) == 0 {
+		return core.RuleResult{
+			RuleID: r.ID(),
+			Passed: true,
+			Detail: "No circular schema references",
+		}
+	}
+
+	var findings []core.Finding
+	var required, recursive []string
+	for _, cycle := range cycles {
+		chain := cycleChain(cycle)
+
+		finding := core.Finding{
+			Location: &core.RuleLocation{
+				Path:        cycle[0].path,
+				Component:   cycle[0].source,
+				SpecSection: "components",
+			},
+			Metadata: map[string]string{
+				"ref_chain":    cycleRefChain(cycle),
+				"cycle_length": fmt.Sprintf("%d", len(cycle)),
+			},
+		}
+
+		if exit := cycleExit(cycle); exit != nil {
+			recursive = append(recursive, chain)
+			finding.Detail = fmt.Sprintf("%s is recursive and can terminate: %s.%s is optional", chain, exit.source, exit.via)
+			finding.Severity = "info"
+			finding.Metadata["cycle_type"] = "recursive"
+		} else {
+			required = append(required, chain)
+			finding.Detail = fmt.Sprintf("%s is a cycle of required references, so no finite instance exists", chain)
+			finding.Severity = "error"
+			finding.Metadata["cycle_type"] = "required"
+		}
+		findings = append(findings, finding)
+	}
+
+	metadata := map[string]string{
+		"required_cycles":  fmt.Sprintf("%d", len(required)),
+		"recursive_cycles": fmt.Sprintf("%d", len(recursive)),
+	}
+	if truncated {
+		metadata["truncated"] = fmt.Sprintf("only the first %d cycles are reported", maxReportedCycles)
+	}
+
+	// Recursive trees such as a node with optional children are legitimate
+	if len(required) == 0 {
+		return core.RuleResult{
+			RuleID:   r.ID(),
+			Passed:   true,
+			Detail:   fmt.Sprintf("%d recursive schema cycles, all can terminate: %s", len(recursive), strings.Join(recursive[:min(3, len(recursive))], "; ")),
+			Metadata: metadata,
+			Findings: findings,
+		}
+	}
+
+	return core.RuleResult{
+		RuleID:   r.ID(),
+		Passed:   false,
+		Detail:   fmt.Sprintf("Found %d schema cycles that cannot be instantiated: %s", len(required), strings.Join(required[:min(3, len(required))], "; ")),
+		Severity: "error",
+		Category: "schema_integrity",
+		Suggestion: &core.ActionableFix{
+			Title:       "Break Required Reference Cycles",
+			Description: "Make at least one reference of each cycle optional: drop it from required, mark the schema nullable, or turn it into an array",
+			Example: `Agent:
+  type: object
+  required: [id]        # workspace is no longer required
+  properties:
+    id:
+      type: string
+    workspace:
+      $ref: '#/components/schemas/Workspace'`,
+			References: []string{
+				"https://json-schema.org/understanding-json-schema/structuring#recursion",
+			},
+			SchemaRef: "https://spec.openapis.org/oas/v3.1.0#schema-object",
+		},
+		Impact: &core.ImpactAnalysis{
+			Severity:    "error",
+			Category:    "schema_integrity",
+			Description: "Schemas in a required reference cycle cannot be instantiated, and break code generators and validators that expand references",
+		},
+		Metadata: metadata,
+		Findings: findings,
+	}
+}
+
+// schemaReferenceGraph maps every component schema to the component schemas it references
+func schemaReferenceGraph(spec *openapi3.T) map[string][]schemaEdge {
+	graph := map[string][]schemaEdge{}
+	if spec == nil || spec.Components == nil {
+		return graph
+	}
+
+	names := make([]string, 0, len(spec.Components.Schemas))
+	for name := range spec.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		walker := &referenceWalker{source: name, edges: map[string]schemaEdge{}}
+		walker.walk(spec.Components.Schemas[name], "$.components.schemas."+name, "", false)
+
+		// Parallel references to the same schema are kept once, a required one
+		// if any: it alone forces the target into every instance
+		targets := make([]string, 0, len(walker.edges))
+		for target := range walker.edges {
+			targets = append(targets, target)
+		}
+		sort.Strings(targets)
+		for _, target := range targets {
+			graph[name] = append(graph[name], walker.edges[target])
+		}
+	}
+	return graph
+}
+
+// referenceWalker collects the references of a component schema to other
+// component schemas. Inline schemas are walked; referenced schemas are not.
+type referenceWalker struct {
+	source string
+	edges  map[string]schemaEdge
+}
+
+func (w *referenceWalker) walk(ref *openapi3.SchemaRef, path, via string, optional bool) {
+	if ref == nil {
+		return
+	}
+
+	// A reference ends the walk. The component itself may be one, as an alias of another schema.
+	if ref.Ref != "" {
+		target := strings.TrimPrefix(ref.Ref, componentSchemaPrefix)
+		if target == ref.Ref || strings.Contains(target, "/") {
+			return // External or nested reference, not part of the component graph
+		}
+		if ref.Value != nil && ref.Value.Nullable {
+			optional = true
+		}
+		if via == "" {
+			via = "$ref"
+		}
+		w.addEdge(schemaEdge{source: w.source, target: target, path: path, via: via, optional: optional})
+		return
+	}
+
+	schema := ref.Value
+	if schema == nil {
+		return
+	}
+	if schema.Nullable {
+		optional = true
+	}
+
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		w.walk(schema.Properties[name], path+".properties."+name, joinVia(via, "properties."+name), optional || !required[name])
+	}
+
+	// An array may be empty unless it requires items
+	w.walk(schema.Items, path+".items", joinVia(via, "items"), optional || schema.MinItems == 0)
+	w.walk(schema.AdditionalProperties.Schema, path+".additionalProperties", joinVia(via, "additionalProperties"), true)
+
+	for i, sub := range schema.AllOf {
+		w.walk(sub, fmt.Sprintf("%s.allOf.%d", path, i), joinVia(via, fmt.Sprintf("allOf[%d]", i)), optional)
+	}
+	// A choice between alternatives can pick one outside the cycle
+	for i, sub := range schema.OneOf {
+		w.walk(sub, fmt.Sprintf("%s.oneOf.%d", path, i), joinVia(via, fmt.Sprintf("oneOf[%d]", i)), optional || len(schema.OneOf) > 1)
+	}
+	for i, sub := range schema.AnyOf {
+		w.walk(sub, fmt.Sprintf("%s.anyOf.%d", path, i), joinVia(via, fmt.Sprintf("anyOf[%d]", i)), optional || len(schema.AnyOf) > 1)
+	}
+}
+
+func (w *referenceWalker) addEdge(edge schemaEdge) {
+	existing, ok := w.edges[edge.target]
+	if !ok || existing.optional && !edge.optional {
+		w.edges[edge.target] = edge
+	}
+}
+
+func joinVia(via, step string) string {
+	if via == "" {
+		return step
+	}
+	return via + "." + step
+}
+
+// findSchemaCycles lists every distinct cycle of the graph once, starting
+// from its alphabetically first schema, with Johnson's algorithm: schemas
+// outside a cycle are never searched and the search blocks schemas that cannot
+// lead back to the start, so the time grows with the number of cycles found
+// rather than the number of paths. It reports whether the list was cut off
+// at maxReportedCycles.
+func findSchemaCycles(graph map[string][]schemaEdge) ([]schemaCycle, bool) {
+	names := make([]string, 0, len(graph))
+	for name := range graph {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var cycles []schemaCycle
+	truncated := false
+
+	components := schemaComponents(graph, names, func(string) bool { return true })
+	for _, start := range names {
+		// Only the schemas after start that share its component can close a
+		// cycle through start; cycles through earlier schemas are already listed
+		component := components[start]
+		local := schemaComponents(graph, names, func(name string) bool {
+			return name >= start && components[name] == component
+		})
+		within := func(name string) bool {
+			id, ok := local[name]
+			return ok && id == local[start]
+		}
+
+		blocked := map[string]bool{}
+		blockedBy := map[string]map[string]bool{}
+		var unblock func(name string)
+		unblock = func(name string) {
+			blocked[name] = false
+			for waiting := range blockedBy[name] {
+				delete(blockedBy[name], waiting)
+				if blocked[waiting] {
+					unblock(waiting)
+				}
+			}
+		}
+
+		var stack schemaCycle
+		var circuit func(current string) bool
+		circuit = func(current string) bool {
+			closed := false
+			blocked[current] = true
+			for _, edge := range graph[current] {
+				if truncated {
+					return closed
+				}
+				if !within(edge.target) {
+					continue
+				}
+				if edge.target == start {
+					if len(cycles) == maxReportedCycles {
+						truncated = true
+						return closed
+					}
+					cycle := make(schemaCycle, len(stack), len(stack)+1)
+					copy(cycle, stack)
+					cycles = append(cycles, append(cycle, edge))
+					closed = true
+				} else if !blocked[edge.target] {
+					stack = append(stack, edge)
+					if circuit(edge.target) {
+						closed = true
+					}
+					stack = stack[:len(stack)-1]
+				}
+			}
+
+			if closed {
+				unblock(current)
+			} else {
+				for _, edge := range graph[current] {
+					if within(edge.target) {
+						if blockedBy[edge.target] == nil {
+							blockedBy[edge.target] = map[string]bool{}
+						}
+						blockedBy[edge.target][current] = true
+					}
+				}
+			}
+			return closed
+		}
+
+		circuit(start)
+		if truncated {
+			break
+		}
+	}
+	return cycles, truncated
+}
+
+// schemaComponents splits the schemas accepted by keep into strongly
+// connected components (Tarjan's algorithm) and returns the component of each
+func schemaComponents(graph map[string][]schemaEdge, names []string, keep func(string) bool) map[string]int {
+	index := map[string]int{}
+	lowLink := map[string]int{}
+	onStack := map[string]bool{}
+	components := map[string]int{}
+	var stack []string
+	next, count := 0, 0
+
+	var connect func(name string)
+	connect = func(name string) {
+		index[name] = next
+		lowLink[name] = next
+		next++
+		stack = append(stack, name)
+		onStack[name] = true
+
+		for _, edge := range graph[name] {
+			if !keep(edge.target) {
+				continue
+			}
+			if _, seen := index[edge.target]; !seen {
+				connect(edge.target)
+				lowLink[name] = min(lowLink[name], lowLink[edge.target])
+			} else if onStack[edge.target] {
+				lowLink[name] = min(lowLink[name], index[edge.target])
+			}
+		}
+
+		if lowLink[name] == index[name] {
+			for {
+				member := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[member] = false
+				components[member] = count
+				if member == name {
+					break
+				}
+			}
+			count++
+		}
+	}
+
+	for _, name := range names {
+		if _, seen := index[name]; !seen && keep(name) {
+			connect(name)
+		}
+	}
+	return components
+}
+
+// cycleExit returns the first reference of a cycle that can be left out, nil
+// when every reference is required
+func cycleExit(cycle schemaCycle) *schemaEdge {
+	for i := range cycle {
+		if cycle[i].optional {
+			return &cycle[i]
+		}
+	}
+	return nil
+}
+
+// cycleChain describes a cycle by schema name, e.g. Agent → Workspace → Agent
+func cycleChain(cycle schemaCycle) string {
+	names := []string{cycle[0].source}
+	for _, edge := range cycle {
+		names = append(names, edge.target)
+	}
+	return strings.Join(names, " → ")
+}
+
+// cycleRefChain describes a cycle by reference, e.g.
+// #/components/schemas/Agent → #/components/schemas/Workspace → #/components/schemas/Agent
+func cycleRefChain(cycle schemaCycle) string {
+	refs := []string{componentSchemaPrefix + cycle[0].source}
+	for _, edge := range cycle {
+		refs = append(refs, componentSchemaPrefix+edge.target)
+	}
+	return strings.Join(refs, " → ")
+}
@@ -0,0 +1,449 @@
+package rules
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// SecurityRules returns the rules checking that security schemes are defined
+// consistently and actually protect the operations
+func SecurityRules() []core.Rule {
+	return []core.Rule{
+		&SecurityCoverageRule{},
+		&SecuritySchemeReferenceRule{},
+		&OAuth2ScopeRule{},
+		&APIKeyInQueryRule{},
+		&BasicAuthTLSRule{},
+		&UnusedSecuritySchemeRule{},
+	}
+}
+
+// securityRequirement is a security requirement together with where it is declared
+type securityRequirement struct {
+	requirement openapi3.SecurityRequirement
+	path        string // JSON path of the requirement
+	operation   string // Operation declaring it, e.g. GET /users; empty for the global security
+}
+
+// securityRequirements returns the global security requirements followed by
+// those of every operation
+func securityRequirements(spec *openapi3.T) []securityRequirement {
+	var requirements []securityRequirement
+	for i, requirement := range spec.Security {
+		requirements = append(requirements, securityRequirement{
+			requirement: requirement,
+			path:        fmt.Sprintf("$.security.%d", i),
+		})
+	}
+	for _, op := range specOperations(spec) {
+		if op.op.Security == nil {
+			continue
+		}
+		for i, requirement := range *op.op.Security {
+			requirements = append(requirements, securityRequirement{
+				requirement: requirement,
+				path:        fmt.Sprintf("%s.security.%d", operationLocation(op.path, op.method).Path, i),
+				operation:   op.method + " " + op.path,
+			})
+		}
+	}
+	return requirements
+}
+
+// describe names where a requirement is declared, for finding details
+func (r securityRequirement) describe() string {
+	if r.operation == "" {
+		return "global security"
+	}
+	return r.operation
+}
+
+// location builds the location of a requirement
+func (r securityRequirement) location() *core.RuleLocation {
+	return &core.RuleLocation{
+		Path:        r.path,
+		Component:   "security",
+		SpecSection: "security",
+	}
+}
+
+// securityScheme returns a security scheme by name, nil when it is not defined
+func securityScheme(spec *openapi3.T, name string) *openapi3.SecurityScheme {
+	if spec.Components == nil {
+		return nil
+	}
+	scheme := spec.Components.SecuritySchemes[name]
+	if scheme == nil {
+		return nil
+	}
+	return scheme.Value
+}
+
+// securitySchemeNames returns the names of the defined security schemes, sorted
+func securitySchemeNames(spec *openapi3.T) []string {
+	if spec.Components == nil {
+		return nil
+	}
+	names := make([]string, 0, len(spec.Components.SecuritySchemes))
+	for name := range spec.Components.SecuritySchemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortedSchemeNames returns the scheme names of a requirement, sorted
+func sortedSchemeNames(requirement openapi3.SecurityRequirement) []string {
+	names := make([]string, 0, len(requirement))
+	for name := range requirement {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// securitySchemeLocation builds the location of a security scheme
+func securitySchemeLocation(name string) *core.RuleLocation {
+	return &core.RuleLocation{
+		Path:        "$.components.securitySchemes." + name,
+		Component:   name,
+		SpecSection: "components",
+	}
+}
+
+// SecurityCoverageRule checks that every operation is covered by the global
+// security or its own. Operations declaring an empty security list are
+// explicitly public and pass.
+type SecurityCoverageRule struct{}
+
+func (r *SecurityCoverageRule) ID() string {
+	return "security-operation-coverage"
+}
+
+func (r *SecurityCoverageRule) Description() string {
+	return "Every operation should be covered by global or operation-level security"
+}
+
+func (r *SecurityCoverageRule) AppliesTo(version string) bool {
+	return strings.HasPrefix(version, "3.")
+}
+
+func (r *SecurityCoverageRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
+	var findings []core.Finding
+	if len(ctx.Spec.Security) == 0 {
+		for _, op := range specOperations(ctx.Spec) {
+			if op.op.Security != nil {
+				continue
+			}
+			findings = append(findings, core.Finding{
+				Detail:   fmt.Sprintf("%s %s has no security requirement", op.method, op.path),
+				Location: operationLocation(op.path, op.method),
+			})
+		}
+	}
+
+	return findingsResult(r.ID(), "warning", "security", findings,
+		"All operations are covered by security requirements",
+		"operations are not covered by any security requirement",
+		&core.ActionableFix{
+			Title:       "Apply Security to Every Operation",
+			Description: "Declare a global security requirement, and mark public operations with an empty security list",
+			Example: `security:
+  - bearerAuth: []
+paths:
+  /health:
+    get:
+      security: []   # Public`,
+			References: []string{"https://spec.openapis.org/oas/v3.1.0#security-requirement-object"},
+			SchemaRef:  "https://spec.openapis.org/oas/v3.1.0#security-requirement-object",
+		})
+}
+
+// SecuritySchemeReferenceRule checks that security requirements only name
+// defined security schemes
+type SecuritySchemeReferenceRule struct{}
+
+func (r *SecuritySchemeReferenceRule) ID() string {
+	return "security-scheme-defined"
+}
+
+func (r *SecuritySchemeReferenceRule) Description() string {
+	return "Security requirements must reference defined security schemes"
+}
+
+func (r *SecuritySchemeReferenceRule) AppliesTo(version string) bool {
+	return strings.HasPrefix(version, "3.")
+}
+
+func (r *SecuritySchemeReferenceRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
+	var findings []core.Finding
+	for _, requirement := range securityRequirements(ctx.Spec) {
+		for _, name := range sortedSchemeNames(requirement.requirement) {
+			if securityScheme(ctx.Spec, name) != nil {
+				continue
+			}
+			findings = append(findings, core.Finding{
+				Detail:   fmt.Sprintf("%s references undefined security scheme %s", requirement.describe(), name),
+				Location: requirement.location(),
+				Metadata: map[string]string{"scheme": name},
+			})
+		}
+	}
+
+	return findingsResult(r.ID(), "error", "security", findings,
+		"All security requirements reference defined schemes",
+		"security requirements reference undefined schemes",
+		&core.ActionableFix{
+			Title:       "Define the Referenced Security Schemes",
+			Description: "Every name in a security requirement must be a key of components.securitySchemes",
+			Example: `components:
+  securitySchemes:
+    bearerAuth:
+      type: http
+      scheme: bearer`,
+			References: []string{"https://spec.openapis.org/oas/v3.1.0#security-scheme-object"},
+			SchemaRef:  "https://spec.openapis.org/oas/v3.1.0#security-scheme-object",
+		})
+}
+
+// OAuth2ScopeRule checks that the scopes required of OAuth2 schemes are
+// declared by at least one flow of the scheme
+type OAuth2ScopeRule struct{}
+
+func (r *OAuth2ScopeRule) ID() string {
+	return "security-oauth2-scopes"
+}
+
+func (r *OAuth2ScopeRule) Description() string {
+	return "OAuth2 scopes required by operations must be declared in the scheme's flows"
+}
+
+func (r *OAuth2ScopeRule) AppliesTo(version string) bool {
+	return strings.HasPrefix(version, "3.")
+}
+
+func (r *OAuth2ScopeRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
+	var findings []core.Finding
+	for _, requirement := range securityRequirements(ctx.Spec) {
+		for _, name := range sortedSchemeNames(requirement.requirement) {
+			scheme := securityScheme(ctx.Spec, name)
+			if scheme == nil || scheme.Type != "oauth2" {
+				continue
+			}
+			declared := oauth2Scopes(scheme)
+			for _, scope := range requirement.requirement[name] {
+				if declared[scope] {
+					continue
+				}
+				findings = append(findings, core.Finding{
+					Detail:   fmt.Sprintf("%s requires scope %s of %s, which no flow declares", requirement.describe(), scope, name),
+					Location: requirement.location(),
+					Metadata: map[string]string{"scheme": name, "scope": scope},
+				})
+			}
+		}
+	}
+
+	return findingsResult(r.ID(), "error", "security", findings,
+		"All required OAuth2 scopes are declared",
+		"required OAuth2 scopes are not declared",
+		&core.ActionableFix{
+			Title:       "Declare the Required Scopes",
+			Description: "List every scope operations require in the scopes of the OAuth2 flows",
+			Example: `oauth2:
+  type: oauth2
+  flows:
+    authorizationCode:
+      authorizationUrl: https://example.com/oauth/authorize
+      tokenUrl: https://example.com/oauth/token
+      scopes:
+        users:read: Read users`,
+			References: []string{"https://spec.openapis.org/oas/v3.1.0#oauth-flow-object"},
+			SchemaRef:  "https://spec.openapis.org/oas/v3.1.0#oauth-flow-object",
+		})
+}
+
+// oauth2Scopes returns the scopes declared by the flows of an OAuth2 scheme
+func oauth2Scopes(scheme *openapi3.SecurityScheme) map[string]bool {
+	scopes := map[string]bool{}
+	if scheme.Flows == nil {
+		return scopes
+	}
+	for _, flow := range []*openapi3.OAuthFlow{scheme.Flows.Implicit, scheme.Flows.Password, scheme.Flows.ClientCredentials, scheme.Flows.AuthorizationCode} {
+		if flow == nil {
+			continue
+		}
+		for scope := range flow.Scopes {
+			scopes[scope] = true
+		}
+	}
+	return scopes
+}
+
+// APIKeyInQueryRule checks that API keys are not passed in the query string,
+// where they end up in server logs, proxies and browser history
+type APIKeyInQueryRule struct{}
+
+func (r *APIKeyInQueryRule) ID() string {
+	return "security-no-apikey-in-query"
+}
+
+func (r *APIKeyInQueryRule) Description() string {
+	return "API keys should not be passed in the query string"
+}
+
+func (r *APIKeyInQueryRule) AppliesTo(version string) bool {
+	return strings.HasPrefix(version, "3.")
+}
+
+func (r *APIKeyInQueryRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
+	var findings []core.Finding
+	for _, name := range securitySchemeNames(ctx.Spec) {
+		scheme := securityScheme(ctx.Spec, name)
+		if scheme == nil || scheme.Type != "apiKey" || scheme.In != "query" {
+			continue
+		}
+		location := securitySchemeLocation(name)
+		location.Path += ".in"
+		findings = append(findings, core.Finding{
+			Detail:   fmt.Sprintf("API key scheme %s is passed in the query parameter %s", name, scheme.Name),
+			Location: location,
+		})
+	}
+
+	return findingsResult(r.ID(), "warning", "security", findings,
+		"No API key is passed in the query string",
+		"API keys are passed in the query string",
+		&core.ActionableFix{
+			Title:       "Pass API Keys in a Header",
+			Description: "Query strings are logged by servers and proxies; send API keys in a header instead",
+			Example: `apiKey:
+  type: apiKey
+  in: header
+  name: X-API-Key`,
+			References: []string{"https://owasp.org/www-community/vulnerabilities/Information_exposure_through_query_strings_in_url"},
+			SchemaRef:  "https://spec.openapis.org/oas/v3.1.0#security-scheme-object",
+		})
+}
+
+// BasicAuthTLSRule checks that HTTP basic authentication is only offered on
+// servers using TLS, since basic credentials are sent in clear text. Servers
+// on localhost are development servers and are not reported.
+type BasicAuthTLSRule struct{}
+
+func (r *BasicAuthTLSRule) ID() string {
+	return "security-basic-auth-tls"
+}
+
+func (r *BasicAuthTLSRule) Description() string {
+	return "HTTP basic authentication must not be used with servers without TLS"
+}
+
+func (r *BasicAuthTLSRule) AppliesTo(version string) bool {
+	return strings.HasPrefix(version, "3.")
+}
+
+func (r *BasicAuthTLSRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
+	var basic []string
+	for _, name := range securitySchemeNames(ctx.Spec) {
+		scheme := securityScheme(ctx.Spec, name)
+		if scheme != nil && scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "basic") {
+			basic = append(basic, name)
+		}
+	}
+
+	var findings []core.Finding
+	if len(basic) > 0 {
+		for i, server := range ctx.Spec.Servers {
+			if server == nil || !isPlainHTTPServer(server.URL) {
+				continue
+			}
+			findings = append(findings, core.Finding{
+				Detail: fmt.Sprintf("Server %s does not use TLS but basic authentication (%s) is defined", server.URL, strings.Join(basic, ", ")),
+				Location: &core.RuleLocation{
+					Path:        fmt.Sprintf("$.servers.%d.url", i),
+					Component:   "server",
+					SpecSection: "servers",
+				},
+			})
+		}
+	}
+
+	return findingsResult(r.ID(), "error", "security", findings,
+		"Basic authentication is only used over TLS",
+		"servers without TLS accept basic authentication",
+		&core.ActionableFix{
+			Title:       "Serve the API over HTTPS",
+			Description: "Basic authentication sends the password with every request; only offer it on https servers",
+			Example: `servers:
+  - url: https://api.example.com`,
+			References: []string{"https://www.rfc-editor.org/rfc/rfc7617#section-4"},
+			SchemaRef:  "https://spec.openapis.org/oas/v3.1.0#server-object",
+		})
+}
+
+// isPlainHTTPServer reports whether a server URL uses http on a host other than localhost
+func isPlainHTTPServer(serverURL string) bool {
+	parsed, err := url.Parse(serverURL)
+	if err != nil || !strings.EqualFold(parsed.Scheme, "http") {
+		return false
+	}
+	switch parsed.Hostname() {
+	case "localhost", "127.0.0.1", "::1":
+		return false
+	}
+	return true
+}
+
+// UnusedSecuritySchemeRule reports security schemes that no security
+// requirement references. They suggest protection the API does not apply.
+type UnusedSecuritySchemeRule struct{}
+
+func (r *UnusedSecuritySchemeRule) ID() string {
+	return "security-unused-scheme"
+}
+
+func (r *UnusedSecuritySchemeRule) Description() string {
+	return "Defined security schemes should be used by a security requirement"
+}
+
+func (r *UnusedSecuritySchemeRule) AppliesTo(version string) bool {
+	return strings.HasPrefix(version, "3.")
+}
+
+func (r *UnusedSecuritySchemeRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
+	used := map[string]bool{}
+	for _, requirement := range securityRequirements(ctx.Spec) {
+		for name := range requirement.requirement {
+			used[name] = true
+		}
+	}
+
+	var findings []core.Finding
+	for _, name := range securitySchemeNames(ctx.Spec) {
+		if used[name] {
+			continue
+		}
+		findings = append(findings, core.Finding{
+			Detail:   fmt.Sprintf("Security scheme %s is not used by any security requirement", name),
+			Location: securitySchemeLocation(name),
+		})
+	}
+
+	return findingsResult(r.ID(), "info", "security", findings,
+		"All security schemes are used",
+		"security schemes are not used",
+		&core.ActionableFix{
+			Title:       "Apply or Remove Unused Security Schemes",
+			Description: "Reference the scheme from the global security or an operation, or remove it",
+			Example: `security:
+  - apiKey: []`,
+			References: []string{"https://spec.openapis.org/oas/v3.1.0#security-requirement-object"},
+			SchemaRef:  "https://spec.openapis.org/oas/v3.1.0#security-requirement-object",
+		})
+}
@@ -0,0 +1,596 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// sensitiveKind classifies the data a name refers to
+type sensitiveKind int
+
+const (
+	kindPII        sensitiveKind = iota // Personal data, e.g. a social security number
+	kindPHI                             // Protected health information, e.g. a medical record number
+	kindCredential                      // A secret the client sends, e.g. a password
+	kindToken                           // A bearer secret, e.g. an API key
+)
+
+func (k sensitiveKind) String() string {
+	switch k {
+	case kindPHI:
+		return "PHI"
+	case kindCredential, kindToken:
+		return "secret"
+	default:
+		return "PII"
+	}
+}
+
+// sensitiveTerm is an entry of the dictionary of sensitive names
+type sensitiveTerm struct {
+	label string
+	kind  sensitiveKind
+	exact bool // Only the whole name matches; names such as nextPageToken are not secrets
+}
+
+// sensitiveDictionary maps normalized names (lower case, letters and digits
+// only) to the data they refer to
+var sensitiveDictionary = map[string]sensitiveTerm{
+	"ssn":                  {"social security number", kindPII, false},
+	"socialsecuritynumber": {"social security number", kindPII, false},
+	"taxid":                {"tax ID", kindPII, false},
+	"nationalid":           {"national ID", kindPII, false},
+	"dob":                  {"date of birth", kindPII, false},
+	"dateofbirth":          {"date of birth", kindPII, false},
+	"birthdate":            {"date of birth", kindPII, false},
+	"passportnumber":       {"passport number", kindPII, false},
+	"driverslicense":       {"driver's license", kindPII, false},
+	"driverlicense":        {"driver's license", kindPII, false},
+	"driverslicensenumber": {"driver's license", kindPII, false},
+	"cardnumber":           {"card number", kindPII, false},
+	"creditcard":           {"card number", kindPII, false},
+	"creditcardnumber":     {"card number", kindPII, false},
+	"cvv":                  {"card security code", kindPII, false},
+	"cvc":                  {"card security code", kindPII, false},
+	"iban":                 {"bank account number", kindPII, false},
+	"bankaccountnumber":    {"bank account number", kindPII, false},
+	"mrn":                  {"medical record number", kindPHI, false},
+	"medicalrecordnumber":  {"medical record number", kindPHI, false},
+	"memberid":             {"health plan member ID", kindPHI, false},
+	"subscriberid":         {"health plan subscriber ID", kindPHI, false},
+	"healthplanid":         {"health plan ID", kindPHI, false},
+	"diagnosis":            {"diagnosis", kindPHI, false},
+	"diagnosiscode":        {"diagnosis", kindPHI, false},
+	"prescription":         {"prescription", kindPHI, false},
+	"password":             {"password", kindCredential, false},
+	"passwd":               {"password", kindCredential, false},
+	"passphrase":           {"passphrase", kindCredential, false},
+	"secret":               {"secret", kindCredential, false},
+	"clientsecret":         {"client secret", kindCredential, false},
+	"privatekey":           {"private key", kindCredential, false},
+	"token":                {"token", kindToken, true},
+	"accesstoken":          {"access token", kindToken, true},
+	"refreshtoken":         {"refresh token", kindToken, true},
+	"idtoken":              {"ID token", kindToken, true},
+	"authtoken":            {"auth token", kindToken, true},
+	"apitoken":             {"API token", kindToken, true},
+	"apikey":               {"API key", kindToken, true},
+	"sessionid":            {"session ID", kindToken, true},
+	"sessiontoken":         {"session token", kindToken, true},
+}
+
+// Patterns of example values that look like real data rather than placeholders
+var (
+	ssnPattern        = regexp.MustCompile(`\b(\d{3})-(\d{2})-(\d{4})\b`)
+	cardPattern       = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+	emailPattern      = regexp.MustCompile(`[A-Za-z0-9._%+-]+@((?:[A-Za-z0-9-]+\.)+[A-Za-z]{2,})`)
+	jwtPattern        = regexp.MustCompile(`eyJ[A-Za-z0-9_-]{5,}\.eyJ[A-Za-z0-9_-]{5,}\.[A-Za-z0-9_-]{10,}`)
+	awsKeyPattern     = regexp.MustCompile(`\bAKIA[0-9A-Z]{16}\b`)
+	liveKeyPattern    = regexp.MustCompile(`\b(?:sk|rk)_live_[0-9A-Za-z]{10,}|\bghp_[0-9A-Za-z]{36}\b`)
+	privateKeyPattern = regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`)
+)
+
+// testCardNumbers are the card numbers payment providers publish for testing
+var testCardNumbers = map[string]bool{
+	"4111111111111111": true,
+	"4242424242424242": true,
+	"4000056655665556": true,
+	"5555555555554444": true,
+	"5105105105105100": true,
+	"378282246310005":  true,
+	"6011111111111117": true,
+}
+
+// SensitiveDataRule looks for personal data (PII), protected health
+// information (PHI) and secrets in parameter names, schema property names and
+// examples. Findings about secrets are security findings, findings about
+// personal and health data are compliance findings. The dictionary of
+// sensitive names can be extended, or trimmed, from the config.
+type SensitiveDataRule struct {
+	PII     []string // Additional names of personal data
+	PHI     []string // Additional names of health information
+	Secrets []string // Additional names of secrets
+	Ignore  []string // Built-in names that are not sensitive in this API
+}
+
+func (r *SensitiveDataRule) ID() string {
+	return "sensitive-data-exposure"
+}
+
+func (r *SensitiveDataRule) Description() string {
+	return "Personal data, health information and secrets should not be exposed in URLs, readable fields or examples"
+}
+
+func (r *SensitiveDataRule) AppliesTo(version string) bool {
+	return strings.HasPrefix(version, "3.")
+}
+
+// Configure reads the pii, phi, secrets and ignore options
+func (r *SensitiveDataRule) Configure(options core.RuleOptions) error {
+	if err := options.CheckKeys("pii", "phi", "secrets", "ignore"); err != nil {
+		return err
+	}
+
+	for key, target := range map[string]*[]string{"pii": &r.PII, "phi": &r.PHI, "secrets": &r.Secrets, "ignore": &r.Ignore} {
+		values, err := options.Strings(key)
+		if err != nil {
+			return err
+		}
+		for _, value := range values {
+			if normalizeName(value) == "" {
+				return fmt.Errorf("option %s contains an empty name", key)
+			}
+		}
+		*target = values
+	}
+	return nil
+}
+
+// dictionary returns the built-in dictionary with the configured changes
+func (r *SensitiveDataRule) dictionary() map[string]sensitiveTerm {
+	dictionary := make(map[string]sensitiveTerm, len(sensitiveDictionary))
+	for name, term := range sensitiveDictionary {
+		dictionary[name] = term
+	}
+	for _, extra := range []struct {
+		names []string
+		kind  sensitiveKind
+	}{{r.PII, kindPII}, {r.PHI, kindPHI}, {r.Secrets, kindCredential}} {
+		for _, name := range extra.names {
+			dictionary[normalizeName(name)] = sensitiveTerm{label: name, kind: extra.kind}
+		}
+	}
+	for _, name := range r.Ignore {
+		delete(dictionary, normalizeName(name))
+	}
+	return dictionary
+}
+
+func (r *SensitiveDataRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
+	scanner := &sensitiveScanner{
+		dictionary: r.dictionary(),
+		visited:    make(map[*openapi3.Schema]bool),
+	}
+	scanner.scanSpec(ctx.Spec)
+
+	// Fields holding personal or health data are worth an inventory, but holding
+	// such data exposes nothing by itself. The inventory is kept apart from the
+	// exposures, so it reads the same whether or not the rule fails.
+	var findings []core.Finding
+	var inventory []string
+	severity := "info"
+	for _, finding := range scanner.findings {
+		if finding.Severity == "info" {
+			inventory = append(inventory, finding.Detail)
+			continue
+		}
+		findings = append(findings, finding)
+		if finding.Severity == "error" || severity == "info" {
+			severity = finding.Severity
+		}
+	}
+	var metadata map[string]string
+	if len(inventory) > 0 {
+		metadata = map[string]string{
+			"personal_data_fields": fmt.Sprintf("%d", len(inventory)),
+			"personal_data":        strings.Join(inventory, "; "),
+		}
+	}
+
+	if len(findings) == 0 && len(inventory) > 0 {
+		return core.RuleResult{
+			RuleID:   r.ID(),
+			Passed:   true,
+			Detail:   fmt.Sprintf("%d fields hold personal or health data, none is exposed", len(inventory)),
+			Metadata: metadata,
+		}
+	}
+
+	result := findingsResult(r.ID(), severity, "compliance", findings,
+		"No sensitive data exposure found",
+		"potential sensitive data exposures",
+		&core.ActionableFix{
+			Title:       "Protect Sensitive Data",
+			Description: "Keep secrets and personal data out of URLs, mark secret fields writeOnly, and use obviously fake example values",
+			Example: `Login:
+  type: object
+  properties:
+    password:
+      type: string
+      format: password
+      writeOnly: true
+Patient:
+  type: object
+  properties:
+    ssn:
+      type: string
+      example: 000-00-0000   # Placeholder, not a real number`,
+			References: []string{
+				"https://owasp.org/API-Security/editions/2023/en/0xa3-broken-object-property-level-authorization/",
+				"https://www.hhs.gov/hipaa/for-professionals/privacy/special-topics/de-identification/index.html",
+			},
+			SchemaRef: "https://spec.openapis.org/oas/v3.1.0#schema-object",
+		})
+	result.Metadata = metadata
+	return result
+}
+
+// sensitiveScanner walks the parameters, schemas and examples of a spec
+type sensitiveScanner struct {
+	dictionary map[string]sensitiveTerm
+	visited    map[*openapi3.Schema]bool
+	findings   []core.Finding
+}
+
+func (s *sensitiveScanner) add(detail, severity, category, path string, metadata map[string]string) {
+	s.findings = append(s.findings, core.Finding{
+		Detail:   detail,
+		Severity: severity,
+		Category: category,
+		Location: &core.RuleLocation{Path: path},
+		Metadata: metadata,
+	})
+}
+
+// scanSpec scans the components first, then what operations declare inline.
+// Referenced components are only scanned once, where they are defined.
+func (s *sensitiveScanner) scanSpec(spec *openapi3.T) {
+	if spec == nil {
+		return
+	}
+
+	if components := spec.Components; components != nil {
+		for _, name := range sortedKeys(components.Schemas) {
+			s.scanSchema(components.Schemas[name], "$.components.schemas."+name, "Schema "+name, "")
+		}
+		for _, name := range sortedKeys(components.Parameters) {
+			if parameter := components.Parameters[name]; parameter != nil && parameter.Ref == "" {
+				s.scanParameter(parameter.Value, "$.components.parameters."+name, "Parameter "+name)
+			}
+		}
+		for _, name := range sortedKeys(components.RequestBodies) {
+			if body := components.RequestBodies[name]; body != nil && body.Ref == "" && body.Value != nil {
+				s.scanContent(body.Value.Content, "$.components.requestBodies."+name, "Request body "+name)
+			}
+		}
+		for _, name := range sortedKeys(components.Responses) {
+			if response := components.Responses[name]; response != nil && response.Ref == "" && response.Value != nil {
+				s.scanContent(response.Value.Content, "$.components.responses."+name, "Response "+name)
+			}
+		}
+		for _, name := range sortedKeys(components.Examples) {
+			if example := components.Examples[name]; example != nil && example.Ref == "" && example.Value != nil {
+				s.scanExample(example.Value.Value, "$.components.examples."+name+".value", "Example "+name)
+			}
+		}
+	}
+
+	for _, path := range sortedKeys(spec.Paths) {
+		if item := spec.Paths[path]; item != nil {
+			s.scanParameters(item.Parameters, "$.paths."+path, path)
+		}
+	}
+	for _, op := range specOperations(spec) {
+		jsonPath := operationLocation(op.path, op.method).Path
+		owner := op.method + " " + op.path
+
+		s.scanParameters(op.op.Parameters, jsonPath, owner)
+		if body := op.op.RequestBody; body != nil && body.Ref == "" && body.Value != nil {
+			s.scanContent(body.Value.Content, jsonPath+".requestBody", owner+" request body")
+		}
+		for _, code := range sortedKeys(op.op.Responses) {
+			if response := op.op.Responses[code]; response != nil && response.Ref == "" && response.Value != nil {
+				s.scanContent(response.Value.Content, jsonPath+".responses."+code, owner+" response "+code)
+			}
+		}
+	}
+}
+
+func (s *sensitiveScanner) scanParameters(parameters openapi3.Parameters, path, owner string) {
+	for i, parameter := range parameters {
+		if parameter != nil && parameter.Ref == "" {
+			s.scanParameter(parameter.Value, fmt.Sprintf("%s.parameters.%d", path, i), owner)
+		}
+	}
+}
+
+// scanParameter checks the name of a parameter and its examples. Query and
+// path parameters end up in URLs, which servers, proxies and browsers log.
+func (s *sensitiveScanner) scanParameter(parameter *openapi3.Parameter, path, owner string) {
+	if parameter == nil {
+		return
+	}
+
+	if parameter.In == openapi3.ParameterInQuery || parameter.In == openapi3.ParameterInPath {
+		if term, ok := s.match(parameter.Name); ok {
+			metadata := map[string]string{"parameter": parameter.Name, "data": term.label, "kind": term.kind.String()}
+			if term.kind == kindCredential || term.kind == kindToken {
+				s.add(fmt.Sprintf("%s %s parameter %s passes a secret (%s) in the URL", owner, parameter.In, parameter.Name, term.label),
+					"error", "security", path, metadata)
+			} else {
+				s.add(fmt.Sprintf("%s %s parameter %s exposes %s (%s) in the URL", owner, parameter.In, parameter.Name, term.kind, term.label),
+					"warning", "compliance", path, metadata)
+			}
+		}
+	}
+
+	where := fmt.Sprintf("%s parameter %s", owner, parameter.Name)
+	s.scanExample(parameter.Example, path+".example", where)
+	for _, name := range sortedKeys(parameter.Examples) {
+		if example := parameter.Examples[name]; example != nil && example.Ref == "" && example.Value != nil {
+			s.scanExample(example.Value.Value, path+".examples."+name+".value", where)
+		}
+	}
+	s.scanSchema(parameter.Schema, path+".schema", where, "")
+}
+
+func (s *sensitiveScanner) scanContent(content openapi3.Content, path, owner string) {
+	for _, mediaType := range sortedKeys(content) {
+		media := content[mediaType]
+		if media == nil {
+			continue
+		}
+		mediaPath := path + ".content." + mediaType
+		s.scanExample(media.Example, mediaPath+".example", owner)
+		for _, name := range sortedKeys(media.Examples) {
+			if example := media.Examples[name]; example != nil && example.Ref == "" && example.Value != nil {
+				s.scanExample(example.Value.Value, mediaPath+".examples."+name+".value", owner)
+			}
+		}
+		s.scanSchema(media.Schema, mediaPath+".schema", owner, "")
+	}
+}
+
+// scanSchema checks the property names and examples of an inline schema and
+// the schemas nested in it. field is the dotted path of the schema within owner.
+func (s *sensitiveScanner) scanSchema(ref *openapi3.SchemaRef, path, owner, field string) {
+	if ref == nil || ref.Ref != "" || ref.Value == nil || s.visited[ref.Value] {
+		return
+	}
+	schema := ref.Value
+	s.visited[schema] = true
+
+	where := owner
+	if field != "" {
+		where = owner + " property " + field
+	}
+	s.scanExample(schema.Example, path+".example", where)
+
+	for _, name := range sortedKeys(schema.Properties) {
+		property := schema.Properties[name]
+		propertyPath := path + ".properties." + name
+		propertyField := name
+		if field != "" {
+			propertyField = field + "." + name
+		}
+
+		if term, ok := s.match(name); ok && property != nil && property.Value != nil {
+			metadata := map[string]string{"property": propertyField, "data": term.label, "kind": term.kind.String()}
+			switch term.kind {
+			case kindCredential:
+				// Secrets the client sends must never be returned
+				if !property.Value.WriteOnly {
+					s.add(fmt.Sprintf("%s property %s holds a secret (%s) but is not writeOnly", owner, propertyField, term.label),
+						"warning", "security", propertyPath, metadata)
+				}
+			case kindPII, kindPHI:
+				s.add(fmt.Sprintf("%s property %s looks like %s (%s)", owner, propertyField, term.kind, term.label),
+					"info", "compliance", propertyPath, metadata)
+			}
+		}
+		s.scanSchema(property, propertyPath, owner, propertyField)
+	}
+
+	s.scanSchema(schema.Items, path+".items", owner, field)
+	s.scanSchema(schema.AdditionalProperties.Schema, path+".additionalProperties", owner, field)
+	for i, sub := range schema.AllOf {
+		s.scanSchema(sub, fmt.Sprintf("%s.allOf.%d", path, i), owner, field)
+	}
+	for i, sub := range schema.OneOf {
+		s.scanSchema(sub, fmt.Sprintf("%s.oneOf.%d", path, i), owner, field)
+	}
+	for i, sub := range schema.AnyOf {
+		s.scanSchema(sub, fmt.Sprintf("%s.anyOf.%d", path, i), owner, field)
+	}
+}
+
+// scanExample reports the first realistic-looking sensitive value of an example
+func (s *sensitiveScanner) scanExample(value interface{}, path, owner string) {
+	label, secret := sensitiveValue(value)
+	if label == "" {
+		return
+	}
+	metadata := map[string]string{"data": label}
+	if secret {
+		s.add(fmt.Sprintf("%s example contains what looks like a real %s", owner, label), "error", "security", path, metadata)
+	} else {
+		s.add(fmt.Sprintf("%s example contains a realistic %s", owner, label), "warning", "compliance", path, metadata)
+	}
+}
+
+// match looks a name up in the dictionary. Besides the whole name, trailing
+// words match too, so patientSSN and subscriber_date_of_birth are found;
+// exact terms only match the whole name.
+func (s *sensitiveScanner) match(name string) (sensitiveTerm, bool) {
+	words := splitWords(name)
+	for i := range words {
+		term, ok := s.dictionary[strings.Join(words[i:], "")]
+		if ok && (i == 0 || !term.exact) {
+			return term, true
+		}
+	}
+	return sensitiveTerm{}, false
+}
+
+// sensitiveValue returns what sensitive data an example value looks like,
+// searching nested objects and arrays, and whether it is a secret. It returns
+// an empty label for values that are not sensitive or are obvious placeholders.
+func sensitiveValue(value interface{}) (string, bool) {
+	switch value := value.(type) {
+	case string:
+		return sensitiveString(value)
+	case map[string]interface{}:
+		for _, key := range sortedKeys(value) {
+			if label, secret := sensitiveValue(value[key]); label != "" {
+				return label, secret
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			if label, secret := sensitiveValue(item); label != "" {
+				return label, secret
+			}
+		}
+	}
+	return "", false
+}
+
+func sensitiveString(value string) (string, bool) {
+	switch {
+	case privateKeyPattern.MatchString(value):
+		return "private key", true
+	case awsKeyPattern.MatchString(value):
+		return "AWS access key", true
+	case liveKeyPattern.MatchString(value):
+		return "live API key", true
+	case jwtPattern.MatchString(value):
+		return "JSON Web Token", true
+	}
+
+	for _, match := range ssnPattern.FindAllStringSubmatch(value, -1) {
+		if isRealisticSSN(match[1], match[2], match[3]) {
+			return "social security number", false
+		}
+	}
+	for _, match := range cardPattern.FindAllString(value, -1) {
+		if isRealisticCardNumber(match) {
+			return "card number", false
+		}
+	}
+	for _, match := range emailPattern.FindAllStringSubmatch(value, -1) {
+		if !isReservedDomain(match[1]) {
+			return "email address", false
+		}
+	}
+	return "", false
+}
+
+// isRealisticSSN rules out numbers that are never issued and common placeholders
+func isRealisticSSN(area, group, serial string) bool {
+	if area == "000" || area == "666" || area[0] == '9' || group == "00" || serial == "0000" {
+		return false
+	}
+	digits := area + group + serial
+	return digits != "123456789" && strings.Count(digits, digits[:1]) != len(digits)
+}
+
+// isRealisticCardNumber reports whether a number passes the Luhn check and is
+// not a published test card or a repeated digit
+func isRealisticCardNumber(number string) bool {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(number)
+	if testCardNumbers[digits] || strings.Count(digits, digits[:1]) == len(digits) {
+		return false
+	}
+
+	sum := 0
+	for i := range digits {
+		digit := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 1 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+	return sum%10 == 0
+}
+
+// isReservedDomain reports whether an email domain is reserved for
+// documentation and testing (RFC 2606)
+func isReservedDomain(domain string) bool {
+	domain = strings.ToLower(domain)
+	for _, reserved := range []string{"example.com", "example.org", "example.net"} {
+		if domain == reserved || strings.HasSuffix(domain, "."+reserved) {
+			return true
+		}
+	}
+	for _, tld := range []string{".example", ".test", ".invalid", ".localhost"} {
+		if strings.HasSuffix(domain, tld) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeName lower-cases a name and drops everything but letters and digits
+func normalizeName(name string) string {
+	return strings.Join(splitWords(name), "")
+}
+
+// splitWords splits a parameter or property name into lower-case words at
+// separators and case changes: patientSSNNumber gives patient, ssn, number
+func splitWords(name string) []string {
+	var words []string
+	var word []rune
+	runes := []rune(name)
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if i > 0 && unicode.IsUpper(r) {
+			previous := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || unicode.IsUpper(previous) && nextIsLower {
+				flush()
+			}
+		}
+		word = append(word, r)
+	}
+	flush()
+	return words
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,118 @@
+package runner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/copyleftdev/specgrade/core"
+)
+
+// Fingerprint identifies a finding independently of its line number: it hashes
+// the rule ID and the JSON path of the finding, falling back to the finding
+// detail for findings without a location
+func Fingerprint(ruleID string, finding core.Finding) string {
+	key := ruleID + "\x00"
+	if finding.Location != nil && finding.Location.Path != "" {
+		key += finding.Location.Path
+	} else {
+		key += "\x00" + finding.Detail
+	}
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:16])
+}
+
+// BaselineEntries records the current findings of a spec for a baseline
+func BaselineEntries(spec string, results []core.RuleResult) []core.BaselineEntry {
+	var entries []core.BaselineEntry
+	for _, result := range results {
+		for _, finding := range result.Issues() {
+			entry := core.BaselineEntry{
+				Fingerprint: Fingerprint(result.RuleID, finding),
+				Spec:        spec,
+				RuleID:      result.RuleID,
+				Detail:      finding.Detail,
+			}
+			if finding.Location != nil {
+				entry.Path = finding.Location.Path
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// KnownEntries returns the baseline entries a spec is compared with: those
+// recorded for the spec. Fingerprints do not include the spec, so entries of
+// other specs would hide new findings at the same location. Only when a
+// single spec is graded against a baseline of a single spec are all entries
+// used, so the baseline still applies when the spec is passed with a
+// different path.
+func KnownEntries(baseline *core.Baseline, spec string, singleSpec bool) []core.BaselineEntry {
+	if baseline == nil {
+		return nil
+	}
+	if singleSpec {
+		specs := map[string]bool{}
+		for _, entry := range baseline.Findings {
+			specs[entry.Spec] = true
+		}
+		if len(specs) == 1 {
+			return baseline.Findings
+		}
+	}
+	return baseline.ForSpec(spec)
+}
+
+// ApplyBaseline marks the findings recorded in the baseline as known and moves
+// them into result.Known, so only new findings count towards the grade. A
+// result whose findings are all known passes. Each baseline entry matches at
+// most one finding, so a rule reporting the same location twice as often as
+// before still has a new finding.
+func ApplyBaseline(results []core.RuleResult, entries []core.BaselineEntry) *core.BaselineSummary {
+	remaining := make(map[string]int, len(entries))
+	for _, entry := range entries {
+		remaining[entry.Fingerprint]++
+	}
+
+	summary := &core.BaselineSummary{}
+	for i := range results {
+		result := &results[i]
+		if result.Passed {
+			continue
+		}
+
+		issues := result.Issues()
+		kept := make([]core.Finding, 0, len(issues))
+		for _, finding := range issues {
+			fingerprint := Fingerprint(result.RuleID, finding)
+			if remaining[fingerprint] == 0 {
+				kept = append(kept, finding)
+				continue
+			}
+			remaining[fingerprint]--
+			finding.Known = true
+			result.Known = append(result.Known, finding)
+		}
+
+		summary.New += len(kept)
+		summary.Known += len(result.Known)
+
+		switch {
+		case len(result.Known) == 0:
+			continue
+		case len(kept) == 0:
+			result.Passed = true
+			result.Findings = nil
+			result.Detail = fmt.Sprintf("All findings already known (%d)", len(result.Known))
+		default:
+			result.Findings = kept
+			result.Detail = fmt.Sprintf("%s (%d already known)", result.Detail, len(result.Known))
+		}
+	}
+
+	for _, count := range remaining {
+		summary.Fixed += count
+	}
+	return summary
+}
@@ -1,14 +1,27 @@
 package runner
 
 import (
+	"context"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+
 	"github.com/copyleftdev/specgrade/core"
 	"github.com/copyleftdev/specgrade/registry"
 )
 
+// DefaultRuleTimeout bounds how long a single rule may take to evaluate
+const DefaultRuleTimeout = 30 * time.Second
+
 // Runner executes validation rules against OpenAPI specs
 type Runner struct {
-	registry  *registry.RuleRegistry
-	skipRules map[string]bool
+	registry    *registry.RuleRegistry
+	skipRules   map[string]bool
+	workers     int
+	ruleTimeout time.Duration
+	severities  map[string]string
 }
 
 // NewRunner creates a new rule runner
@@ -19,25 +32,74 @@ func NewRunner(registry *registry.RuleRegistry, skipRules []string) *Runner {
 	}
 
 	return &Runner{
-		registry:  registry,
-		skipRules: skipMap,
+		registry:    registry,
+		skipRules:   skipMap,
+		workers:     runtime.NumCPU(),
+		ruleTimeout: DefaultRuleTimeout,
+	}
+}
+
+// SetWorkers sets how many rules are evaluated in parallel
+func (r *Runner) SetWorkers(workers int) {
+	if workers < 1 {
+		workers = 1
 	}
+	r.workers = workers
+}
+
+// SetRuleTimeout sets how long a single rule may run before it is reported as
+// errored. A zero timeout disables the per-rule limit.
+func (r *Runner) SetRuleTimeout(timeout time.Duration) {
+	r.ruleTimeout = timeout
+}
+
+// SetSeverityOverrides sets the severity reported for the results and findings
+// of specific rules, as configured in the rules section of the config
+func (r *Runner) SetSeverityOverrides(severities map[string]string) {
+	r.severities = severities
 }
 
 // Run executes all applicable rules for the given spec and version
 func (r *Runner) Run(spec *core.SpecContext) []core.RuleResult {
-	rules := r.registry.RulesForVersion(spec.Version)
-	results := make([]core.RuleResult, 0, len(rules))
+	return r.RunContext(context.Background(), spec)
+}
 
-	for _, rule := range rules {
+// RunContext executes all applicable rules on a worker pool. Results keep the
+// registration order of the rules. Rules that panic, exceed the rule timeout or
+// are cut off by the context yield an errored result instead of aborting the run.
+func (r *Runner) RunContext(ctx context.Context, spec *core.SpecContext) []core.RuleResult {
+	var rules []core.Rule
+	for _, rule := range r.registry.RulesForVersion(spec.Version) {
 		// Skip rules that are in the skip list
-		if r.skipRules[rule.ID()] {
-			continue
+		if !r.skipRules[rule.ID()] {
+			rules = append(rules, rule)
 		}
+	}
 
-		result := rule.Evaluate(spec)
-		results = append(results, result)
+	suppressions := collectSuppressions(spec.Spec)
+	results := make([]core.RuleResult, len(rules))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	workers := r.workers
+	if workers > len(rules) {
+		workers = len(rules)
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = r.evaluate(ctx, rules[i], spec, suppressions)
+			}
+		}()
+	}
+
+	for i := range rules {
+		jobs <- i
 	}
+	close(jobs)
+	wg.Wait()
 
 	return results
 }
@@ -53,6 +115,87 @@ func (r *Runner) RunRule(spec *core.SpecContext, ruleID string) (*core.RuleResul
 		return nil, nil // Rule doesn't apply to this version
 	}
 
-	result := rule.Evaluate(spec)
+	result := r.evaluate(context.Background(), rule, spec, collectSuppressions(spec.Spec))
 	return &result, nil
 }
+
+// evaluate runs a single rule and applies the suppressions declared in the spec,
+// converting a panic, a timeout or a cancelled context into an errored result.
+// Rules cannot be interrupted, so a rule that times out keeps running in the
+// background until it returns.
+func (r *Runner) evaluate(ctx context.Context, rule core.Rule, spec *core.SpecContext, suppressions []suppression) core.RuleResult {
+	if err := ctx.Err(); err != nil {
+		return erroredResult(rule, fmt.Sprintf("rule was not evaluated: %v", err), "")
+	}
+
+	ruleCtx := ctx
+	if r.ruleTimeout > 0 {
+		var cancel context.CancelFunc
+		ruleCtx, cancel = context.WithTimeout(ctx, r.ruleTimeout)
+		defer cancel()
+	}
+
+	done := make(chan core.RuleResult, 1)
+	go func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				done <- erroredResult(rule, fmt.Sprintf("rule panicked: %v", recovered), string(debug.Stack()))
+			}
+		}()
+
+		result := rule.Evaluate(spec)
+		resolveLocations(spec, &result)
+		r.applySeverity(&result)
+		applySuppressions(spec, &result, suppressions)
+		done <- result
+	}()
+
+	select {
+	case result := <-done:
+		return result
+	case <-ruleCtx.Done():
+		if ctx.Err() == nil {
+			return erroredResult(rule, fmt.Sprintf("rule timed out after %s", r.ruleTimeout), "")
+		}
+		return erroredResult(rule, fmt.Sprintf("rule did not finish: %v", ctx.Err()), "")
+	}
+}
+
+// erroredResult builds the failed result reported for a rule that could not be evaluated
+func erroredResult(rule core.Rule, detail, stack string) core.RuleResult {
+	result := core.RuleResult{
+		RuleID:   rule.ID(),
+		Passed:   false,
+		Detail:   detail,
+		Severity: "error",
+		Category: "runtime",
+		Errored:  true,
+	}
+	if stack != "" {
+		result.Metadata = map[string]string{"stack": stack}
+	}
+	return result
+}
+
+// applySeverity replaces the severity of a result and its findings when the rule has an override
+func (r *Runner) applySeverity(result *core.RuleResult) {
+	severity, ok := r.severities[result.RuleID]
+	if !ok {
+		return
+	}
+
+	if !result.Passed {
+		result.Severity = severity
+	}
+	for i := range result.Findings {
+		result.Findings[i].Severity = severity
+	}
+}
+
+// resolveLocations fills in source file, line and column for the result and its findings
+func resolveLocations(spec *core.SpecContext, result *core.RuleResult) {
+	spec.Resolve(result.Location)
+	for i := range result.Findings {
+		spec.Resolve(result.Findings[i].Location)
+	}
+}
@@ -0,0 +1,192 @@
+package runner
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const (
+	// IgnoreExtension lists the rules suppressed on an operation, path item or schema
+	IgnoreExtension = "x-specgrade-ignore"
+
+	// IgnoreReasonExtension gives the reason for the rules listed in IgnoreExtension
+	IgnoreReasonExtension = "x-specgrade-ignore-reason"
+)
+
+// suppression is the set of rules ignored within one object of the spec
+type suppression struct {
+	scope   string            // JSON path of the object carrying the extension
+	reasons map[string]string // Rule ID -> reason, empty when the reason is missing
+}
+
+// collectSuppressions finds the x-specgrade-ignore extensions on path items,
+// operations and component schemas. The extension is either a list of rule IDs
+// with the reason in x-specgrade-ignore-reason, a list of {rule, reason}
+// objects, or a mapping of rule IDs to reasons.
+func collectSuppressions(spec *openapi3.T) []suppression {
+	if spec == nil {
+		return nil
+	}
+
+	var suppressions []suppression
+	add := func(scope string, extensions map[string]interface{}) {
+		if s, ok := parseSuppression(scope, extensions); ok {
+			suppressions = append(suppressions, s)
+		}
+	}
+
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		item := spec.Paths[path]
+		if item == nil {
+			continue
+		}
+		add("$.paths."+path, item.Extensions)
+		for method, op := range item.Operations() {
+			add("$.paths."+path+"."+strings.ToLower(method), op.Extensions)
+		}
+	}
+
+	if spec.Components != nil {
+		for name, schema := range spec.Components.Schemas {
+			if schema != nil && schema.Value != nil {
+				add("$.components.schemas."+name, schema.Value.Extensions)
+			}
+		}
+	}
+
+	return suppressions
+}
+
+// parseSuppression reads the ignore extensions of a single object
+func parseSuppression(scope string, extensions map[string]interface{}) (suppression, bool) {
+	raw, ok := extensions[IgnoreExtension]
+	if !ok {
+		return suppression{}, false
+	}
+
+	sharedReason, _ := extensions[IgnoreReasonExtension].(string)
+	s := suppression{
+		scope:   scope,
+		reasons: make(map[string]string),
+	}
+
+	switch value := raw.(type) {
+	case string:
+		s.reasons[value] = strings.TrimSpace(sharedReason)
+	case []interface{}:
+		for _, item := range value {
+			switch entry := item.(type) {
+			case string:
+				s.reasons[entry] = strings.TrimSpace(sharedReason)
+			case map[string]interface{}:
+				ruleID, _ := entry["rule"].(string)
+				reason, _ := entry["reason"].(string)
+				if reason == "" {
+					reason = sharedReason
+				}
+				if ruleID != "" {
+					s.reasons[ruleID] = strings.TrimSpace(reason)
+				}
+			}
+		}
+	case map[string]interface{}:
+		for ruleID, item := range value {
+			reason, _ := item.(string)
+			s.reasons[ruleID] = strings.TrimSpace(reason)
+		}
+	}
+
+	return s, len(s.reasons) > 0
+}
+
+// covers reports whether a location lies within the suppression's scope
+func (s suppression) covers(location *core.RuleLocation) bool {
+	if location == nil || location.Path == "" {
+		return false
+	}
+	return location.Path == s.scope || strings.HasPrefix(location.Path, s.scope+".")
+}
+
+// findSuppression returns the most specific suppression of the rule covering the location
+func findSuppression(suppressions []suppression, ruleID string, location *core.RuleLocation) (suppression, bool) {
+	var best suppression
+	found := false
+	for _, s := range suppressions {
+		if _, ok := s.reasons[ruleID]; !ok || !s.covers(location) {
+			continue
+		}
+		if !found || len(s.scope) > len(best.scope) {
+			best = s
+			found = true
+		}
+	}
+	return best, found
+}
+
+// applySuppressions moves the findings covered by a suppression with a reason
+// into result.Suppressed. A result whose findings are all suppressed passes.
+// Suppressions without a reason are not honored; the affected findings are
+// flagged in their metadata instead.
+func applySuppressions(spec *core.SpecContext, result *core.RuleResult, suppressions []suppression) {
+	if result.Passed || len(suppressions) == 0 {
+		return
+	}
+
+	issues := result.Issues()
+	kept := make([]core.Finding, 0, len(issues))
+	for _, finding := range issues {
+		s, ok := findSuppression(suppressions, result.RuleID, finding.Location)
+		if !ok {
+			kept = append(kept, finding)
+			continue
+		}
+
+		reason := s.reasons[result.RuleID]
+		if reason == "" {
+			metadata := make(map[string]string, len(finding.Metadata)+1)
+			for key, value := range finding.Metadata {
+				metadata[key] = value
+			}
+			metadata["suppression_error"] = fmt.Sprintf("%s at %s has no reason, add %s", IgnoreExtension, s.scope, IgnoreReasonExtension)
+			finding.Metadata = metadata
+			kept = append(kept, finding)
+			continue
+		}
+
+		result.Suppressed = append(result.Suppressed, core.Suppression{
+			RuleID:   result.RuleID,
+			Reason:   reason,
+			Detail:   finding.Detail,
+			Location: finding.Location,
+			Declared: spec.Resolve(&core.RuleLocation{Path: s.scope}),
+		})
+	}
+
+	if len(result.Suppressed) == 0 {
+		// Nothing suppressed, keep the result exactly as the rule reported it
+		if len(result.Findings) > 0 {
+			result.Findings = kept
+		}
+		return
+	}
+
+	if len(kept) == 0 {
+		result.Passed = true
+		result.Findings = nil
+		result.Detail = fmt.Sprintf("All findings suppressed (%d)", len(result.Suppressed))
+		return
+	}
+
+	result.Findings = kept
+	result.Detail = fmt.Sprintf("%s (%d suppressed)", result.Detail, len(result.Suppressed))
+}
@@ -0,0 +1,275 @@
+package schema
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+)
+
+// reportSchema is the JSON Schema of the JSON report, published as schema/report.schema.json
+//
+//go:embed report.schema.json
+var reportSchema []byte
+
+// ReportSchema returns the JSON Schema of the JSON report
+func ReportSchema() []byte {
+	return reportSchema
+}
+
+// ValidationError describes where a document does not match the schema
+type ValidationError struct {
+	Path    string // JSON path of the offending value, e.g. $.rules[0].passed
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidateReport validates a JSON report, of a single spec or of several, against
+// the report schema and returns every mismatch, or nil when the report is valid
+func ValidateReport(data []byte) ([]ValidationError, error) {
+	var root map[string]interface{}
+	if err := json.Unmarshal(reportSchema, &root); err != nil {
+		return nil, fmt.Errorf("invalid report schema: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber() // Keeps integers apart from numbers
+	var report interface{}
+	if err := decoder.Decode(&report); err != nil {
+		return nil, fmt.Errorf("report is not valid JSON: %w", err)
+	}
+
+	v := &validator{root: root}
+	v.validate(report, root, "$")
+	return v.errors, nil
+}
+
+// validator checks a document against the subset of JSON Schema 2020-12 the
+// report schema uses: $ref to $defs, oneOf, type, const, enum, properties,
+// required, additionalProperties, items, minimum, maximum and the date-time
+// format. Other keywords are ignored.
+type validator struct {
+	root   map[string]interface{}
+	errors []ValidationError
+}
+
+func (v *validator) fail(path, format string, args ...interface{}) {
+	v.errors = append(v.errors, ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) validate(value interface{}, schema map[string]interface{}, path string) {
+	if ref, ok := schema["$ref"].(string); ok {
+		target, err := v.resolve(ref)
+		if err != nil {
+			v.fail(path, "%v", err)
+			return
+		}
+		v.validate(value, target, path)
+	}
+	if branches, ok := schema["oneOf"].([]interface{}); ok {
+		v.validateOneOf(value, branches, path)
+	}
+
+	if types, ok := schema["type"]; ok && !matchesType(value, types) {
+		v.fail(path, "expected %s, got %s", describeTypes(types), typeOf(value))
+		return
+	}
+
+	if expected, ok := schema["const"]; ok && !equal(value, expected) {
+		v.fail(path, "expected %v, got %v", expected, value)
+	}
+	if options, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, option := range options {
+			if equal(value, option) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.fail(path, "%v is not one of %v", value, options)
+		}
+	}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		v.validateObject(value, schema, path)
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range value {
+				v.validate(item, items, fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	case json.Number:
+		number, _ := new(big.Float).SetString(value.String())
+		if minimum, ok := schema["minimum"].(float64); ok && number.Cmp(big.NewFloat(minimum)) < 0 {
+			v.fail(path, "%s is less than %v", value, minimum)
+		}
+		if maximum, ok := schema["maximum"].(float64); ok && number.Cmp(big.NewFloat(maximum)) > 0 {
+			v.fail(path, "%s is greater than %v", value, maximum)
+		}
+	case string:
+		if schema["format"] == "date-time" {
+			if _, err := time.Parse(time.RFC3339, value); err != nil {
+				v.fail(path, "%q is not an RFC 3339 date-time", value)
+			}
+		}
+	}
+}
+
+func (v *validator) validateObject(object map[string]interface{}, schema map[string]interface{}, path string) {
+	properties, _ := schema["properties"].(map[string]interface{})
+
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, name := range required {
+			if _, ok := object[name.(string)]; !ok {
+				v.fail(path, "missing required property %q", name)
+			}
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		propertyPath := path + "." + name
+		if property, ok := properties[name].(map[string]interface{}); ok {
+			v.validate(object[name], property, propertyPath)
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				v.fail(propertyPath, "unknown property")
+			}
+		case map[string]interface{}:
+			v.validate(object[name], additional, propertyPath)
+		}
+	}
+}
+
+// validateOneOf checks that a value matches exactly one of the branches. When
+// it matches none, the problems of the closest branch are reported.
+func (v *validator) validateOneOf(value interface{}, branches []interface{}, path string) {
+	matched := 0
+	var closest []ValidationError
+	for _, branch := range branches {
+		schema, ok := branch.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		attempt := &validator{root: v.root}
+		attempt.validate(value, schema, path)
+		if len(attempt.errors) == 0 {
+			matched++
+		} else if closest == nil || len(attempt.errors) < len(closest) {
+			closest = attempt.errors
+		}
+	}
+
+	switch {
+	case matched > 1:
+		v.fail(path, "matches %d schemas of oneOf, expected exactly one", matched)
+	case matched == 0:
+		v.errors = append(v.errors, closest...)
+	}
+}
+
+// resolve looks up a local reference such as #/$defs/finding
+func (v *validator) resolve(ref string) (map[string]interface{}, error) {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("unsupported schema reference %s", ref)
+	}
+	var node interface{} = v.root
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		object, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unresolved schema reference %s", ref)
+		}
+		node = object[part]
+	}
+	schema, ok := node.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unresolved schema reference %s", ref)
+	}
+	return schema, nil
+}
+
+// matchesType reports whether a value has one of the types of a type keyword
+func matchesType(value interface{}, types interface{}) bool {
+	switch types := types.(type) {
+	case string:
+		return hasType(value, types)
+	case []interface{}:
+		for _, t := range types {
+			if name, ok := t.(string); ok && hasType(value, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasType(value interface{}, name string) bool {
+	actual := typeOf(value)
+	return actual == name || (name == "number" && actual == "integer")
+}
+
+// typeOf returns the JSON Schema type of a decoded value
+func typeOf(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := value.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func describeTypes(types interface{}) string {
+	if list, ok := types.([]interface{}); ok {
+		names := make([]string, len(list))
+		for i, t := range list {
+			names[i] = fmt.Sprint(t)
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(types)
+}
+
+// equal compares a decoded value with a value from the schema
+func equal(value, expected interface{}) bool {
+	if number, ok := value.(json.Number); ok {
+		if expected, ok := expected.(float64); ok {
+			actual, err := number.Float64()
+			return err == nil && actual == expected
+		}
+		return false
+	}
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return false // The schema only compares with scalars
+	}
+	return value == expected
+}
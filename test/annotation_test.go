@@ -0,0 +1,101 @@
+package test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/copyleftdev/specgrade/reporter"
+)
+
+func annotationReport() *core.Report {
+	return reporter.NewReporter().GenerateReport(&core.SpecContext{Version: "3.1.0"}, []core.RuleResult{
+		{
+			RuleID:   "operation-description",
+			Detail:   "Description issues: 2 missing descriptions",
+			Severity: "warning",
+			Findings: []core.Finding{
+				{Detail: "GET /users has no description", Location: &core.RuleLocation{Path: "$.paths./users.get", File: "paths/users.yaml", Line: 3, Column: 5}},
+				{Detail: "Ratio 100%, needs: a, b", Severity: "error"},
+			},
+		},
+	})
+}
+
+func TestFormatGitHub(t *testing.T) {
+	output := reporter.NewReporter().FormatGitHub(annotationReport(), "api/openapi.yaml")
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	require.Len(t, lines, 3)
+
+	// Source files are relative to the spec directory
+	assert.Equal(t, "::warning file=api/paths/users.yaml,line=3,col=5,title=operation-description::GET /users has no description", lines[0])
+	// Findings without a position annotate the spec file, messages are escaped
+	assert.Equal(t, "::error file=api/openapi.yaml,title=operation-description::Ratio 100%25, needs: a, b", lines[1])
+	assert.True(t, strings.HasPrefix(lines[2], "::notice title=SpecGrade::"))
+}
+
+func TestFormatGitLabCodeQuality(t *testing.T) {
+	output, err := reporter.NewReporter().FormatGitLabCodeQuality(annotationReport(), "api/openapi.yaml")
+	require.NoError(t, err)
+
+	var issues []struct {
+		Description string `json:"description"`
+		CheckName   string `json:"check_name"`
+		Fingerprint string `json:"fingerprint"`
+		Severity    string `json:"severity"`
+		Location    struct {
+			Path  string `json:"path"`
+			Lines struct {
+				Begin int `json:"begin"`
+			} `json:"lines"`
+		} `json:"location"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &issues))
+	require.Len(t, issues, 2)
+
+	assert.Equal(t, "operation-description", issues[0].CheckName)
+	assert.Equal(t, "minor", issues[0].Severity)
+	assert.Equal(t, "api/paths/users.yaml", issues[0].Location.Path)
+	assert.Equal(t, 3, issues[0].Location.Lines.Begin)
+
+	assert.Equal(t, "major", issues[1].Severity)
+	assert.Equal(t, "api/openapi.yaml", issues[1].Location.Path)
+	assert.Equal(t, 1, issues[1].Location.Lines.Begin)
+	assert.NotEqual(t, issues[0].Fingerprint, issues[1].Fingerprint)
+}
+
+// TestGitLabCodeQualityFingerprintsAreUnique checks that findings of one rule at
+// one path, such as two undeclared scopes of an operation, get their own fingerprints
+func TestGitLabCodeQualityFingerprintsAreUnique(t *testing.T) {
+	location := &core.RuleLocation{Path: "$.paths./users.get.security.0"}
+	report := reporter.NewReporter().GenerateReport(&core.SpecContext{Version: "3.1.0"}, []core.RuleResult{
+		{
+			RuleID:   "security-oauth2-scopes",
+			Severity: "error",
+			Findings: []core.Finding{
+				{Detail: "GET /users requires scope admin of oauth, which no flow declares", Location: location},
+				{Detail: "GET /users requires scope write of oauth, which no flow declares", Location: location},
+				{Detail: "GET /users requires scope write of oauth, which no flow declares", Location: location},
+			},
+		},
+	})
+
+	output, err := reporter.NewReporter().FormatGitLabCodeQuality(report, "openapi.yaml")
+	require.NoError(t, err)
+
+	var issues []struct {
+		Fingerprint string `json:"fingerprint"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &issues))
+	require.Len(t, issues, 3)
+
+	fingerprints := map[string]bool{}
+	for _, issue := range issues {
+		fingerprints[issue.Fingerprint] = true
+	}
+	assert.Len(t, fingerprints, 3)
+}
@@ -0,0 +1,137 @@
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/copyleftdev/specgrade/ci"
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/copyleftdev/specgrade/reporter"
+	"github.com/copyleftdev/specgrade/rules"
+	runnerPkg "github.com/copyleftdev/specgrade/runner"
+	"github.com/copyleftdev/specgrade/utils"
+)
+
+// legacySpec has two operations without descriptions
+func legacySpec() *core.SpecContext {
+	return &core.SpecContext{
+		Spec: &openapi3.T{
+			Info: &openapi3.Info{Title: "Legacy API", Version: "1.0.0"},
+			Paths: openapi3.Paths{
+				"/users":  &openapi3.PathItem{Get: &openapi3.Operation{}},
+				"/orders": &openapi3.PathItem{Get: &openapi3.Operation{}},
+			},
+		},
+		Version: "3.1.0",
+	}
+}
+
+func runLegacySpec(spec *core.SpecContext) []core.RuleResult {
+	return runRules(spec, &rules.InfoTitleRule{}, &rules.OperationDescriptionRule{})
+}
+
+func TestBaselineRoundTrip(t *testing.T) {
+	entries := runnerPkg.BaselineEntries("openapi.yaml", runLegacySpec(legacySpec()))
+	require.Len(t, entries, 2)
+	assert.Equal(t, "operation-description", entries[0].RuleID)
+	assert.Equal(t, "$.paths./orders.get", entries[0].Path)
+	assert.Equal(t, "openapi.yaml", entries[0].Spec)
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	require.NoError(t, utils.SaveBaseline(path, &core.Baseline{Version: core.BaselineVersion, Findings: entries}))
+
+	loaded, err := utils.LoadBaseline(path)
+	require.NoError(t, err)
+	assert.Equal(t, entries, loaded.Findings)
+	assert.Len(t, loaded.ForSpec("openapi.yaml"), 2)
+	assert.Empty(t, loaded.ForSpec("other.yaml"))
+
+	require.NoError(t, utils.SaveBaseline(path, &core.Baseline{Version: 99}))
+	_, err = utils.LoadBaseline(path)
+	assert.ErrorContains(t, err, "unsupported baseline version 99")
+}
+
+func TestFingerprintIgnoresLineNumbers(t *testing.T) {
+	finding := core.Finding{
+		Detail:   "GET /users has no description",
+		Location: &core.RuleLocation{Path: "$.paths./users.get", Line: 10},
+	}
+	moved := finding
+	moved.Location = &core.RuleLocation{Path: "$.paths./users.get", Line: 42}
+
+	assert.Equal(t, runnerPkg.Fingerprint("operation-description", finding), runnerPkg.Fingerprint("operation-description", moved))
+	assert.NotEqual(t, runnerPkg.Fingerprint("operation-description", finding), runnerPkg.Fingerprint("operation-operationId-unique", finding))
+}
+
+func TestApplyBaseline(t *testing.T) {
+	known := runnerPkg.BaselineEntries("openapi.yaml", runLegacySpec(legacySpec()))
+
+	t.Run("only known findings", func(t *testing.T) {
+		results := runLegacySpec(legacySpec())
+		summary := runnerPkg.ApplyBaseline(results, known)
+
+		assert.Equal(t, &core.BaselineSummary{Known: 2}, summary)
+		assert.True(t, results[1].Passed)
+		assert.Equal(t, "All findings already known (2)", results[1].Detail)
+		require.Len(t, results[1].Known, 2)
+		assert.True(t, results[1].Known[0].Known)
+
+		report := reporter.NewReporter().GenerateReport(&core.SpecContext{Version: "3.1.0"}, results)
+		report.Baseline = summary
+		assert.Equal(t, "A+", report.Grade)
+		assert.Equal(t, 0, ci.NewExitHandler("B").HandleReport(report))
+	})
+
+	t.Run("new finding", func(t *testing.T) {
+		spec := legacySpec()
+		spec.Spec.Paths["/invoices"] = &openapi3.PathItem{Get: &openapi3.Operation{}}
+		delete(spec.Spec.Paths, "/orders")
+
+		results := runLegacySpec(spec)
+		summary := runnerPkg.ApplyBaseline(results, known)
+
+		assert.Equal(t, &core.BaselineSummary{Known: 1, New: 1, Fixed: 1}, summary)
+		assert.False(t, results[1].Passed)
+		require.Len(t, results[1].Findings, 1)
+		assert.Equal(t, "$.paths./invoices.get", results[1].Findings[0].Location.Path)
+
+		// A new finding fails the build even when the grade meets the threshold
+		report := reporter.NewReporter().GenerateReport(&core.SpecContext{Version: "3.1.0"}, results)
+		report.Baseline = summary
+		assert.Equal(t, 0, ci.NewExitHandler("F").Handle(report.Grade))
+		assert.Equal(t, 1, ci.NewExitHandler("F").HandleReport(report))
+	})
+}
+
+// TestKnownEntriesPerSpec checks that the findings of one spec in a monorepo
+// baseline do not hide new findings at the same location in another spec
+func TestKnownEntriesPerSpec(t *testing.T) {
+	spec := legacySpec()
+	delete(spec.Spec.Paths, "/orders")
+	baseline := &core.Baseline{
+		Version: core.BaselineVersion,
+		Findings: append(
+			runnerPkg.BaselineEntries("services/billing/openapi.yaml", runLegacySpec(spec)),
+			runnerPkg.BaselineEntries("services/users/openapi.yaml", runLegacySpec(legacySpec()))...,
+		),
+	}
+
+	// GET /orders is only known in the users spec
+	known := runnerPkg.KnownEntries(baseline, "services/billing/openapi.yaml", true)
+	require.Len(t, known, 1)
+	summary := runnerPkg.ApplyBaseline(runLegacySpec(legacySpec()), known)
+	assert.Equal(t, &core.BaselineSummary{Known: 1, New: 1}, summary)
+
+	assert.Len(t, runnerPkg.KnownEntries(baseline, "services/users/openapi.yaml", false), 2)
+	assert.Empty(t, runnerPkg.KnownEntries(baseline, "openapi.yaml", true))
+
+	// A baseline of a single spec applies to a single spec passed with another path
+	single := &core.Baseline{Findings: runnerPkg.BaselineEntries("openapi.yaml", runLegacySpec(legacySpec()))}
+	assert.Len(t, runnerPkg.KnownEntries(single, "api/openapi.yaml", true), 2)
+	assert.Empty(t, runnerPkg.KnownEntries(single, "api/openapi.yaml", false))
+	assert.Nil(t, runnerPkg.KnownEntries(nil, "openapi.yaml", true))
+}
@@ -0,0 +1,110 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/copyleftdev/specgrade/reporter"
+)
+
+const complexitySpec = `openapi: 3.1.0
+info:
+  title: Tree API
+  version: 1.0.0
+paths:
+  /nodes:
+    parameters:
+      - name: X-Tenant
+        in: header
+        schema:
+          type: string
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: The nodes
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: "#/components/schemas/Node"
+        "404":
+          $ref: "./errors.yaml#/NotFound"
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: "#/components/schemas/Node"
+      responses:
+        "201":
+          description: Created
+  /owners:
+    get:
+      responses:
+        "200":
+          description: The owners
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Owner"
+components:
+  schemas:
+    Node:
+      type: object
+      properties:
+        name:
+          type: string
+        children:
+          type: array
+          items:
+            $ref: "#/components/schemas/Node"
+    Owner:
+      type: object
+      properties:
+        address:
+          type: object
+          properties:
+            geo:
+              type: object
+              properties:
+                lat:
+                  type: number
+`
+
+func TestAnalyzeComplexity(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "openapi.yaml"), []byte(complexitySpec), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "errors.yaml"), []byte("NotFound:\n  description: Not found\n"), 0644))
+
+	ctx := loadSpecFile(t, filepath.Join(dir, "openapi.yaml"))
+
+	analysis := reporter.AnalyzeComplexity(ctx.Spec)
+	assert.Equal(t, 3, analysis.EndpointCount)
+	assert.Equal(t, 2, analysis.SchemaCount)
+	assert.Equal(t, 2, analysis.ParameterCount)
+	assert.Equal(t, 4, analysis.ResponseCount)
+	assert.Equal(t, 4, analysis.NestingDepth) // Owner.address.geo.lat
+	assert.Equal(t, 1, analysis.CircularRefs) // Node.children
+	assert.Equal(t, 1, analysis.ExternalRefs)
+	assert.Equal(t, 3*2+2+(2+4)/2+5+5+1, analysis.ComplexityScore)
+
+	// The report analyzes the spec it is generated for
+	report := reporter.NewReporter().GenerateReport(ctx, nil)
+	assert.Equal(t, analysis, report.Analytics.SpecComplexity)
+}
+
+func TestAnalyzeComplexityEmptySpec(t *testing.T) {
+	analysis := reporter.AnalyzeComplexity(nil)
+	assert.Equal(t, &core.ComplexityAnalysis{}, analysis)
+}
@@ -0,0 +1,177 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/copyleftdev/specgrade/differ"
+	"github.com/copyleftdev/specgrade/reporter"
+)
+
+func TestCompareClassifiesChanges(t *testing.T) {
+	oldSpec, newSpec := loadSpecFile(t, "./sample-spec/diff/v1.yaml"), loadSpecFile(t, "./sample-spec/diff/v2.yaml")
+	diff := differ.Compare(oldSpec, newSpec)
+
+	type change struct {
+		Type     string
+		Breaking bool
+	}
+	var changes []change
+	for _, c := range diff.Changes {
+		changes = append(changes, change{c.Type, c.Breaking})
+	}
+
+	assert.Equal(t, []change{
+		{differ.PathRemoved, true},
+		{differ.PathAdded, false},
+		{differ.ParameterAdded, true},
+		{differ.EnumNarrowed, true},
+		{differ.PropertyRemoved, true},
+		{differ.PropertyAdded, false},
+		{differ.TypeChanged, true},
+		{differ.SecuritySchemeRemoved, true},
+	}, changes)
+	assert.Equal(t, 6, diff.BreakingChanges)
+	assert.Equal(t, 2, diff.NonBreakingChanges)
+
+	assert.Equal(t, "GET /pets: response 200 (application/json) field items.id: type changed from string to integer", diff.Changes[6].Description)
+	assert.Equal(t, "high", diff.RiskAssessment.OverallRiskLevel)
+	assert.Len(t, diff.RiskAssessment.BreakingChanges, 6)
+
+	// Removals point into the old spec, everything else into the new one
+	assert.Contains(t, diff.Changes[0].Location.FileRef, "v1.yaml:")
+	assert.Contains(t, diff.Changes[2].Location.FileRef, "v2.yaml:")
+}
+
+func TestCompareIdenticalSpecs(t *testing.T) {
+	oldSpec := loadSpecFile(t, "./sample-spec/diff/v1.yaml")
+	diff := differ.Compare(oldSpec, oldSpec)
+
+	assert.Empty(t, diff.Changes)
+	assert.Equal(t, 0, diff.BreakingChanges)
+	assert.Equal(t, "low", diff.RiskAssessment.OverallRiskLevel)
+}
+
+// composedSpec returns a spec whose response schema is composed with allOf,
+// from the properties of its Base component and its own name property
+func composedSpec(base, name string) string {
+	return `openapi: 3.0.3
+info:
+  title: Composed
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+components:
+  schemas:
+    Base:
+      type: object
+      properties:
+` + base + `    User:
+      allOf:
+        - $ref: '#/components/schemas/Base'
+        - type: object
+          properties:
+            name:
+              type: ` + name + `
+`
+}
+
+func TestCompareComposedSchemas(t *testing.T) {
+	oldSpec := loadSpecString(t, composedSpec("        id:\n          type: string\n        email:\n          type: string\n", "string"))
+	newSpec := loadSpecString(t, composedSpec("        id:\n          type: string\n", "integer"))
+	diff := differ.Compare(oldSpec, newSpec)
+
+	require.Len(t, diff.Changes, 2)
+	assert.Equal(t, 2, diff.BreakingChanges)
+	assert.Equal(t, differ.PropertyRemoved, diff.Changes[0].Type)
+	assert.Equal(t, "GET /users: response 200 (application/json): property email was removed", diff.Changes[0].Description)
+	assert.Equal(t, differ.TypeChanged, diff.Changes[1].Type)
+	assert.Equal(t, "GET /users: response 200 (application/json) field name: type changed from string to integer", diff.Changes[1].Description)
+	assert.Equal(t, "openapi.yaml:27:13", diff.Changes[1].Location.FileRef)
+}
+
+// userSpec returns a spec with a single path to a user, named by a parameter
+// of the given name and type
+func userSpec(name, paramType string) string {
+	return `openapi: 3.0.3
+info:
+  title: Users
+  version: 1.0.0
+paths:
+  /users/{` + name + `}:
+    get:
+      parameters:
+        - name: ` + name + `
+          in: path
+          required: true
+          schema:
+            type: ` + paramType + `
+      responses:
+        '200':
+          description: OK
+`
+}
+
+func TestCompareRenamedPathParameters(t *testing.T) {
+	diff := differ.Compare(loadSpecString(t, userSpec("id", "string")), loadSpecString(t, userSpec("userId", "string")))
+	assert.Empty(t, diff.Changes)
+
+	// The renamed parameter is still compared with the one it replaced
+	diff = differ.Compare(loadSpecString(t, userSpec("id", "string")), loadSpecString(t, userSpec("userId", "integer")))
+	require.Len(t, diff.Changes, 1)
+	assert.Equal(t, "GET /users/{userId}: path parameter userId: type changed from string to integer", diff.Changes[0].Description)
+}
+
+func TestCompareRequestAndResponseDirection(t *testing.T) {
+	oldSpec, newSpec := loadSpecFile(t, "./sample-spec/diff/v1.yaml"), loadSpecFile(t, "./sample-spec/diff/v2.yaml")
+
+	// Reversing the diff turns the removed response property into an added one
+	diff := differ.Compare(newSpec, oldSpec)
+	for _, c := range diff.Changes {
+		if c.Type == differ.PropertyAdded {
+			assert.False(t, c.Breaking, c.Description)
+		}
+		if c.Type == differ.EnumWidened {
+			assert.False(t, c.Breaking, "a request enum accepting more values is compatible")
+		}
+	}
+}
+
+func TestDiffReportFormats(t *testing.T) {
+	oldSpec, newSpec := loadSpecFile(t, "./sample-spec/diff/v1.yaml"), loadSpecFile(t, "./sample-spec/diff/v2.yaml")
+	diff := differ.Compare(oldSpec, newSpec)
+	diff.Old, diff.New = "v1.yaml", "v2.yaml"
+
+	rep := reporter.NewReporter()
+	assert.Contains(t, rep.FormatDiffCLI(diff), "💔 Breaking changes: 6")
+	assert.Contains(t, rep.FormatDiffMarkdown(diff), "## Breaking Changes")
+	assert.Contains(t, rep.FormatDiffHTML(diff), "Security scheme apiKey was removed")
+	assert.Contains(t, rep.FormatDiffDeveloperCLI(diff), differ.TypeChanged)
+
+	output, err := rep.FormatDiffJSON(diff)
+	require.NoError(t, err)
+	assert.Contains(t, output, `"breaking_changes": 6`)
+}
+
+// TestDiffGitHubAnnotations checks that changes are annotated at their lines,
+// removals in the old spec and everything else in the new one
+func TestDiffGitHubAnnotations(t *testing.T) {
+	diff := differ.Compare(loadSpecFile(t, "./sample-spec/diff/v1.yaml"), loadSpecFile(t, "./sample-spec/diff/v2.yaml"))
+	diff.Old, diff.New = "sample-spec/diff/v1.yaml", "sample-spec/diff/v2.yaml"
+
+	output := reporter.NewReporter().FormatDiffGitHub(diff)
+	assert.Contains(t, output, "::error file=sample-spec/diff/v1.yaml,line=16,col=3,title=path-removed::Path /pets/{id} was removed\n")
+	assert.Contains(t, output, "::notice file=sample-spec/diff/v2.yaml,line=17,col=3,title=path-added::Path /owners was added\n")
+	assert.Contains(t, output, "::error file=sample-spec/diff/v2.yaml,line=26,col=9,title=type-changed::")
+	assert.Contains(t, output, "6 breaking, 2 non-breaking changes")
+}
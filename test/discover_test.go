@@ -0,0 +1,99 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/copyleftdev/specgrade/fetcher"
+	"github.com/copyleftdev/specgrade/reporter"
+)
+
+func TestDiscoverSpecs(t *testing.T) {
+	root := t.TempDir()
+	for _, file := range []string{
+		"services/billing/api/openapi.yaml",
+		"services/users/api/openapi.json",
+		"services/users/api/swagger.yaml", // openapi.json is preferred
+		"services/legacy/swagger.yml",
+		"node_modules/pkg/openapi.yaml", // never searched
+		"docs/README.md",
+	} {
+		path := filepath.Join(root, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte("openapi: 3.0.0\n"), 0644))
+	}
+
+	tests := []struct {
+		name     string
+		patterns []string
+		expected []string
+	}{
+		{
+			name:     "recursive",
+			patterns: []string{root + "/..."},
+			expected: []string{
+				"services/billing/api/openapi.yaml",
+				"services/legacy/swagger.yml",
+				"services/users/api/openapi.json",
+			},
+		},
+		{
+			name:     "glob",
+			patterns: []string{filepath.Join(root, "services/*/api")},
+			expected: []string{
+				"services/billing/api/openapi.yaml",
+				"services/users/api/openapi.json",
+			},
+		},
+		{
+			name: "files are de-duplicated",
+			patterns: []string{
+				filepath.Join(root, "services/legacy/swagger.yml"),
+				filepath.Join(root, "services/legacy"),
+			},
+			expected: []string{"services/legacy/swagger.yml"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			specs, err := fetcher.DiscoverSpecs(tt.patterns)
+			require.NoError(t, err)
+
+			var relative []string
+			for _, spec := range specs {
+				rel, err := filepath.Rel(root, spec)
+				require.NoError(t, err)
+				relative = append(relative, filepath.ToSlash(rel))
+			}
+			assert.Equal(t, tt.expected, relative)
+		})
+	}
+
+	_, err := fetcher.DiscoverSpecs([]string{filepath.Join(root, "missing/*")})
+	assert.Error(t, err)
+}
+
+func TestGenerateMultiReport(t *testing.T) {
+	rep := reporter.NewReporter()
+
+	multi := rep.GenerateMultiReport([]core.SpecReport{
+		{Target: "a.yaml", Passed: true, Report: &core.Report{Grade: "A", Score: 100}},
+		{Target: "b.yaml", Passed: false, Report: &core.Report{Grade: "D", Score: 80}},
+		{Target: "c.yaml", Error: "failed to load OpenAPI spec"},
+	})
+
+	assert.Equal(t, 3, multi.Aggregate.TotalSpecs)
+	assert.Equal(t, 1, multi.Aggregate.PassedSpecs)
+	assert.Equal(t, 2, multi.Aggregate.FailedSpecs)
+	assert.Equal(t, 1, multi.Aggregate.ErrorSpecs)
+
+	// Specs that failed to load do not drag the average down
+	assert.Equal(t, 90, multi.Aggregate.Score)
+	assert.Equal(t, "A", multi.Aggregate.Grade)
+}
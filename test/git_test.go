@@ -0,0 +1,180 @@
+package test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/copyleftdev/specgrade/ci"
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/copyleftdev/specgrade/fetcher"
+	"github.com/copyleftdev/specgrade/reporter"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// gitRepo creates a repository with one commit on main containing the given files
+func gitRepo(t *testing.T, files map[string]string) string {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+
+	run("init", "-q", "-b", "main")
+	run("config", "user.email", "specgrade@example.com")
+	run("config", "user.name", "SpecGrade")
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	run("add", ".")
+	run("commit", "-q", "-m", "base")
+	run("checkout", "-q", "-b", "feature")
+	return dir
+}
+
+const gitBaseSpec = `openapi: 3.1.0
+info:
+  title: Base API
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      responses:
+        "200":
+          $ref: "./responses.yaml#/Users"
+`
+
+func TestGitSpecLoader(t *testing.T) {
+	dir := gitRepo(t, map[string]string{
+		"api/openapi.yaml":   gitBaseSpec,
+		"api/responses.yaml": "Users:\n  description: The users\n",
+	})
+
+	// The working tree has moved on since the merge base
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "api/openapi.yaml"), []byte("openapi: 3.1.0\ninfo:\n  title: Changed API\n  version: 2.0.0\npaths: {}\n"), 0644))
+
+	loader := fetcher.NewGitSpecLoader(filepath.Join(dir, "api/openapi.yaml"), "main")
+	ctx, err := loader.LoadContext("3.1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "Base API", ctx.Spec.Info.Title)
+	assert.Len(t, loader.Commit(), 40)
+
+	// External $refs resolve against the files of the same revision
+	response := ctx.Spec.Paths["/users"].Get.Responses["200"]
+	require.NotNil(t, response.Value)
+	assert.Equal(t, "The users", *response.Value.Description)
+
+	// Directories are searched for the well-known spec file names
+	ctx, err = fetcher.NewGitSpecLoader(filepath.Join(dir, "api"), "main").LoadContext("3.1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "Base API", ctx.Spec.Info.Title)
+}
+
+func TestGitSpecLoaderReferencesOutsideSpecDir(t *testing.T) {
+	dir := gitRepo(t, map[string]string{
+		"api/openapi.yaml":         strings.Replace(gitBaseSpec, "./responses.yaml", "../common/responses.yaml", 1),
+		"common/responses.yaml":    "Users:\n  $ref: ./users.yaml#/Users\n",
+		"common/users.yaml":        "Users:\n  description: The shared users\n",
+		"other/unrelated.yaml":     "not: [a spec",
+		"other/nested/config.json": "{}",
+	})
+
+	ctx, err := fetcher.NewGitSpecLoader(filepath.Join(dir, "api/openapi.yaml"), "main").LoadContext("3.1.0")
+	require.NoError(t, err)
+	response := ctx.Spec.Paths["/users"].Get.Responses["200"]
+	require.NotNil(t, response.Value)
+	assert.Equal(t, "The shared users", *response.Value.Description)
+}
+
+func TestGitSpecLoaderErrors(t *testing.T) {
+	dir := gitRepo(t, map[string]string{"openapi.yaml": gitBaseSpec})
+
+	// A spec added on the branch did not exist at the merge base
+	newSpec := filepath.Join(dir, "new.yaml")
+	require.NoError(t, os.WriteFile(newSpec, []byte(gitBaseSpec), 0644))
+	_, err := fetcher.NewGitSpecLoader(newSpec, "main").LoadContext("3.1.0")
+	assert.ErrorIs(t, err, fetcher.ErrSpecNotInRevision)
+
+	// So did a spec in a directory added on the branch
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "v2"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "v2/openapi.yaml"), []byte(gitBaseSpec), 0644))
+	_, err = fetcher.NewGitSpecLoader(filepath.Join(dir, "v2/openapi.yaml"), "main").LoadContext("3.1.0")
+	assert.ErrorIs(t, err, fetcher.ErrSpecNotInRevision)
+
+	_, err = fetcher.NewGitSpecLoader(filepath.Join(dir, "openapi.yaml"), "no-such-branch").LoadContext("3.1.0")
+	assert.ErrorContains(t, err, "failed to find the merge base of no-such-branch and HEAD")
+}
+
+func TestExitHandlerSince(t *testing.T) {
+	handler := ci.NewExitHandler("B")
+
+	// A spec below the threshold passes as long as the change introduces no findings
+	report := &core.Report{Grade: "D", Since: &core.SinceSummary{Ref: "main", BaseGrade: "D", FixedFindings: 1}}
+	assert.Equal(t, 0, handler.HandleReport(report))
+
+	report = &core.Report{Grade: "A", Since: &core.SinceSummary{Ref: "main", BaseGrade: "A", NewFindings: 1}}
+	assert.Equal(t, 1, handler.HandleReport(report))
+}
+
+// TestGenerateSinceReport checks that the grade and summary of a report graded
+// against a git revision count the same findings as its rules
+func TestGenerateSinceReport(t *testing.T) {
+	rep := reporter.NewReporter()
+	base := rep.GenerateReport(legacySpec(), runLegacySpec(legacySpec()))
+
+	t.Run("no new findings", func(t *testing.T) {
+		results := runLegacySpec(legacySpec())
+		report := rep.GenerateSinceReport(legacySpec(), results, base, &core.SinceSummary{Ref: "main"})
+
+		assert.Equal(t, "A+", report.Grade)
+		assert.Equal(t, 0, report.Summary.TotalIssues)
+		assert.Len(t, report.Rules[1].Known, 2)
+		assert.Equal(t, base.Grade, report.Since.Grade)
+		assert.Equal(t, base.Score, report.Since.Score)
+		assert.Equal(t, 0, report.Since.ScoreDelta)
+		assert.Equal(t, 0, ci.NewExitHandler("A").HandleReport(report))
+
+		// The whole spec was not touched by applying the since comparison
+		assert.Empty(t, results[1].Known)
+	})
+
+	t.Run("new finding", func(t *testing.T) {
+		spec := legacySpec()
+		spec.Spec.Paths["/invoices"] = &openapi3.PathItem{Get: &openapi3.Operation{}}
+		delete(spec.Spec.Paths, "/orders")
+
+		results := runLegacySpec(spec)
+		report := rep.GenerateSinceReport(spec, results, base, &core.SinceSummary{Ref: "main"})
+
+		require.Len(t, report.Rules[1].Findings, 1)
+		assert.Equal(t, "$.paths./invoices.get", report.Rules[1].Findings[0].Location.Path)
+		assert.Equal(t, 1, report.Summary.TotalIssues)
+		assert.Equal(t, reporter.NewDefaultGrader().Grade(report.Rules), report.Grade)
+		assert.Equal(t, reporter.NewDefaultGrader().Grade(results), report.Since.Grade)
+		assert.Equal(t, 1, report.Since.NewFindings)
+		assert.Equal(t, 1, report.Since.FixedFindings)
+		assert.Equal(t, 1, ci.NewExitHandler("F").HandleReport(report))
+	})
+
+	t.Run("new spec", func(t *testing.T) {
+		report := rep.GenerateSinceReport(legacySpec(), runLegacySpec(legacySpec()), nil, &core.SinceSummary{Ref: "main"})
+
+		assert.Empty(t, report.Since.BaseGrade)
+		assert.Equal(t, 2, report.Since.NewFindings)
+		assert.Equal(t, 2, report.Summary.TotalIssues)
+		assert.Equal(t, report.Grade, report.Since.Grade)
+	})
+}
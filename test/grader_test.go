@@ -145,3 +145,114 @@ func TestGradeBoundaries(t *testing.T) {
 		})
 	}
 }
+
+func TestWeightedScoring(t *testing.T) {
+	grader := reporter.NewDefaultGrader()
+
+	passing := func(ids ...string) []core.RuleResult {
+		results := make([]core.RuleResult, 0, len(ids))
+		for _, id := range ids {
+			results = append(results, core.RuleResult{RuleID: id, Passed: true})
+		}
+		return results
+	}
+
+	tests := []struct {
+		name          string
+		results       []core.RuleResult
+		expectedGrade string
+		expectedScore int
+	}{
+		{
+			name: "failed error costs twice a failed warning",
+			results: append(passing("paths-exist", "info-title", "operation-description"),
+				core.RuleResult{RuleID: "info-version", Passed: false, Severity: "error"}),
+			expectedGrade: "C",
+			expectedScore: 60,
+		},
+		{
+			name: "failed warning",
+			results: append(passing("paths-exist", "info-title", "info-version"),
+				core.RuleResult{RuleID: "operation-description", Passed: false, Severity: "warning"}),
+			expectedGrade: "B",
+			expectedScore: 75,
+		},
+		{
+			name: "failed info costs half a failed warning",
+			results: append(passing("paths-exist", "info-title", "info-version", "operation-description"),
+				core.RuleResult{RuleID: "naming-operation-id", Passed: false, Severity: "info"}),
+			expectedGrade: "A-",
+			expectedScore: 88,
+		},
+		{
+			name: "rule without a severity counts as a warning",
+			results: append(passing("paths-exist", "info-title", "info-version"),
+				core.RuleResult{RuleID: "third-party-rule", Passed: false}),
+			expectedGrade: "B",
+			expectedScore: 75,
+		},
+		{
+			name: "critical failure caps the grade",
+			results: append(passing("info-title", "info-version", "operation-description", "operation-operationId-unique",
+				"oas3-valid-schema-example", "operation-success-response", "oas3-security-defined",
+				"RULE1", "RULE2", "RULE3", "RULE4", "RULE5", "RULE6", "RULE7", "RULE8", "RULE9", "RULE10", "RULE11", "RULE12"),
+				core.RuleResult{RuleID: "paths-exist", Passed: false, Severity: "error"}),
+			expectedGrade: "D",
+			expectedScore: 54,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grade := grader.Grade(tt.results)
+			score := grader.CalculateScore(tt.results)
+
+			if grade != tt.expectedGrade {
+				t.Errorf("Expected grade %s, got %s", tt.expectedGrade, grade)
+			}
+
+			if score != tt.expectedScore {
+				t.Errorf("Expected score %d, got %d", tt.expectedScore, score)
+			}
+		})
+	}
+}
+
+func TestReportIncludesScoreBreakdown(t *testing.T) {
+	rep := reporter.NewReporter()
+
+	report := rep.GenerateReport(&core.SpecContext{Version: "3.1.0"}, []core.RuleResult{
+		{RuleID: "paths-exist", Passed: false, Severity: "error"},
+		{RuleID: "operation-description", Passed: true},
+	})
+
+	if report.Scoring == nil {
+		t.Fatal("Expected a score breakdown in the report")
+	}
+	if report.Scoring.Formula != reporter.ScoringFormula {
+		t.Errorf("Expected formula %q, got %q", reporter.ScoringFormula, report.Scoring.Formula)
+	}
+	if report.Scoring.EarnedPoints != 1 || report.Scoring.PenaltyPoints != 2 {
+		t.Errorf("Expected 1 earned and 2 penalty points, got %v and %v", report.Scoring.EarnedPoints, report.Scoring.PenaltyPoints)
+	}
+	if report.Scoring.Cap != "D" || len(report.Scoring.CappedBy) != 1 || report.Scoring.CappedBy[0] != "paths-exist" {
+		t.Errorf("Expected the grade to be capped at D by paths-exist, got %q by %v", report.Scoring.Cap, report.Scoring.CappedBy)
+	}
+	if report.Grade != "F" {
+		t.Errorf("Expected grade F, got %s", report.Grade)
+	}
+}
+
+func TestProfileRuleSeverityOverride(t *testing.T) {
+	results := []core.RuleResult{
+		{RuleID: "info-title", Passed: true},
+		{RuleID: "oas3-security-defined", Passed: false, Severity: "warning"},
+	}
+
+	profile := reporter.WithRuleSeverities(reporter.BuiltinProfiles()[reporter.DefaultProfile], map[string]string{"oas3-security-defined": "error"})
+	breakdown := reporter.NewProfileGrader(profile).Breakdown(results)
+
+	if breakdown.Rules[1].Severity != "error" || breakdown.PenaltyPoints != 2 {
+		t.Errorf("Expected the profile severity error with 2 penalty points, got %s and %v", breakdown.Rules[1].Severity, breakdown.PenaltyPoints)
+	}
+}
@@ -0,0 +1,75 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/copyleftdev/specgrade/fetcher"
+	"github.com/copyleftdev/specgrade/registry"
+	runnerPkg "github.com/copyleftdev/specgrade/runner"
+)
+
+// minimalSpec is a valid spec without paths that passes the info rules
+const minimalSpec = `openapi: 3.1.0
+info:
+  title: Test API
+  version: 1.0.0
+paths: {}
+`
+
+// schemasSpec is the start of a spec without paths; schemas are appended to it
+const schemasSpec = `openapi: 3.0.3
+info:
+  title: Schemas
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+`
+
+// loadSpecString loads a spec from its YAML or JSON source, graded as OpenAPI 3.1.0
+func loadSpecString(t *testing.T, source string) *core.SpecContext {
+	t.Helper()
+	spec, err := fetcher.NewReaderSpecLoader("openapi.yaml", strings.NewReader(source)).LoadContext("3.1.0")
+	require.NoError(t, err)
+	return spec
+}
+
+// loadSpecFile loads a spec file or directory, graded as OpenAPI 3.1.0
+func loadSpecFile(t *testing.T, path string) *core.SpecContext {
+	t.Helper()
+	spec, err := fetcher.NewSpecLoader(path).LoadContext("3.1.0")
+	require.NoError(t, err)
+	return spec
+}
+
+// evaluateRules evaluates each rule against a spec and returns the results by rule ID
+func evaluateRules(spec *core.SpecContext, rules ...core.Rule) map[string]core.RuleResult {
+	results := make(map[string]core.RuleResult, len(rules))
+	for _, rule := range rules {
+		results[rule.ID()] = rule.Evaluate(spec)
+	}
+	return results
+}
+
+// runRules runs rules through the runner, so suppressions and source positions
+// apply, and returns the results in the order of the rules
+func runRules(spec *core.SpecContext, rules ...core.Rule) []core.RuleResult {
+	reg := registry.NewRuleRegistry()
+	for _, rule := range rules {
+		reg.Register(rule)
+	}
+	return runnerPkg.NewRunner(reg, []string{}).Run(spec)
+}
+
+// findingDetails returns the details of the findings of a result, in order
+func findingDetails(result core.RuleResult) []string {
+	details := make([]string, 0, len(result.Findings))
+	for _, finding := range result.Findings {
+		details = append(details, finding.Detail)
+	}
+	return details
+}
@@ -0,0 +1,90 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/copyleftdev/specgrade/history"
+)
+
+func historyReport(score int, paths ...string) *core.Report {
+	result := core.RuleResult{RuleID: "OPERATION-ID", Detail: "Missing operationId"}
+	for _, path := range paths {
+		result.Findings = append(result.Findings, core.Finding{
+			Detail:   "Missing operationId",
+			Location: &core.RuleLocation{Path: path},
+		})
+	}
+	return &core.Report{Grade: "B", Score: score, Rules: []core.RuleResult{result}}
+}
+
+func TestHistoryRecord(t *testing.T) {
+	store := history.NewStore(t.TempDir())
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// The first run has nothing to compare with
+	trends, err := store.Record("api/openapi.yaml", historyReport(70, "$.paths./a.get", "$.paths./b.get"), start)
+	require.NoError(t, err)
+	assert.Nil(t, trends)
+
+	trends, err = store.Record("api/openapi.yaml", historyReport(80, "$.paths./b.get", "$.paths./c.get"), start.Add(time.Hour))
+	require.NoError(t, err)
+	require.NotNil(t, trends)
+	assert.Equal(t, 70, trends.PreviousScore)
+	assert.Equal(t, 10, trends.ScoreChange)
+	assert.Equal(t, "improving", trends.TrendDirection)
+	assert.Equal(t, 1, trends.FixedIssues)
+	assert.Equal(t, 1, trends.NewIssues)
+
+	trends, err = store.Record("api/openapi.yaml", historyReport(80, "$.paths./b.get", "$.paths./c.get"), start.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, "stable", trends.TrendDirection)
+
+	// Other specs have their own history
+	_, err = store.Record("other.yaml", historyReport(50, "$.paths./a.get"), start)
+	require.NoError(t, err)
+
+	entries, err := store.Entries("api/openapi.yaml")
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, []int{70, 80, 80}, []int{entries[0].Score, entries[1].Score, entries[2].Score})
+	assert.Equal(t, 2, entries[0].Issues)
+
+	specs, err := store.Specs()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api/openapi.yaml", "other.yaml"}, specs)
+}
+
+func TestHistorySpecsIgnoresOtherFiles(t *testing.T) {
+	dir := t.TempDir()
+	store := history.NewStore(dir)
+	_, err := store.Record("api/openapi.yaml", historyReport(70, "$.paths./a.get"), time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	// Files that sort after the entries, and directories without entries, are not runs
+	specDir := filepath.Join(dir, history.SpecKey("api/openapi.yaml"))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "notes.txt"), []byte("not an entry"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "empty"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "empty", ".DS_Store"), nil, 0644))
+
+	specs, err := store.Specs()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api/openapi.yaml"}, specs)
+}
+
+func TestHistoryTrendsDeclining(t *testing.T) {
+	previous := &core.HistoryEntry{Score: 90, Findings: []string{"a"}}
+	current := &core.HistoryEntry{Score: 75, Findings: []string{"a", "a", "b"}}
+
+	trends := history.Trends(previous, current)
+	assert.Equal(t, "declining", trends.TrendDirection)
+	assert.Equal(t, -15, trends.ScoreChange)
+	assert.Equal(t, 0, trends.FixedIssues)
+	assert.Equal(t, 2, trends.NewIssues)
+}
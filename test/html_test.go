@@ -0,0 +1,67 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/copyleftdev/specgrade/reporter"
+	"github.com/copyleftdev/specgrade/rules"
+)
+
+func TestFormatHTML(t *testing.T) {
+	spec := loadSpecFile(t, "sample-spec/openapi.yaml")
+	results := runRules(spec, &rules.InfoTitleRule{}, &rules.ErrorResponseRule{})
+
+	rep := reporter.NewReporter()
+	report := rep.GenerateReport(spec, results)
+	require.NotEmpty(t, report.Operations)
+	assert.Equal(t, core.OperationRef{Path: "/users", Method: "GET", OperationID: "getUsers"}, report.Operations[0])
+
+	page := rep.FormatHTML(report, "sample-spec/openapi.yaml")
+
+	// Self-contained: no external scripts or stylesheets
+	assert.NotContains(t, page, "<script src")
+	assert.NotContains(t, page, "<link")
+
+	// The operation tree counts the findings of each operation
+	assert.Contains(t, page, `data-op="$.paths./users/{userId}.get"`)
+
+	// Findings are filterable and show the source around their line
+	assert.Contains(t, page, `data-severity="warning" data-category="error_handling" data-path="$.paths./users/{userId}.get"`)
+	assert.Contains(t, page, `<span class="line hl"><span class="ln">61</span>    get:</span>`)
+
+	// The suggested fix includes its example as code
+	assert.Contains(t, page, "<pre><code>responses:")
+}
+
+// TestFormatHTMLFindingCategories checks that findings are filtered by their own
+// category, so security findings of a compliance rule show under security
+func TestFormatHTMLFindingCategories(t *testing.T) {
+	spec := loadSpecString(t, sensitiveSpec)
+	rep := reporter.NewReporter()
+	page := rep.FormatHTML(rep.GenerateReport(spec, runRules(spec, &rules.SensitiveDataRule{})), "stdin")
+
+	assert.Contains(t, page, `data-category="compliance"`)
+	assert.Contains(t, page, `<option value="security">security</option>`)
+	assert.Contains(t, page, `data-severity="error" data-category="security" data-path="$.paths./patients.get.parameters.1"`)
+	assert.Contains(t, page, `data-severity="warning" data-category="compliance" data-path="$.paths./patients.get.parameters.0"`)
+}
+
+func TestFormatHTMLEscapesSpecContent(t *testing.T) {
+	rep := reporter.NewReporter()
+	report := rep.GenerateReport(&core.SpecContext{Version: "3.1.0"}, []core.RuleResult{{
+		RuleID:   "operation-description",
+		Detail:   "1 missing descriptions",
+		Severity: "warning",
+		Findings: []core.Finding{{Detail: `GET /<script>alert("x")</script> has no description`}},
+	}})
+
+	page := rep.FormatHTML(report, "stdin")
+	assert.NotContains(t, page, `<script>alert`)
+	assert.Contains(t, page, "&lt;script&gt;")
+	assert.Equal(t, 1, strings.Count(page, "<script>"))
+}
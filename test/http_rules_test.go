@@ -0,0 +1,196 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/copyleftdev/specgrade/rules"
+)
+
+const httpSemanticsSpec = `openapi: 3.0.3
+info:
+  title: HTTP semantics
+  version: 1.0.0
+security:
+  - apiKey: []
+paths:
+  /users:
+    get:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+      responses:
+        '200':
+          description: OK
+          headers:
+            X-RateLimit-Remaining:
+              schema:
+                type: integer
+        4XX:
+          description: Client error
+    post:
+      responses:
+        '200':
+          description: OK
+        '401':
+          description: Unauthorized
+  /users/{userId}:
+    get:
+      responses:
+        '200':
+          description: OK
+        default:
+          description: Error
+    put:
+      responses:
+        '201':
+          description: Replaced
+    delete:
+      responses:
+        '204':
+          description: Deleted
+          content:
+            application/json:
+              schema:
+                type: object
+        '401':
+          description: Unauthorized
+        '403':
+          description: Forbidden
+        '404':
+          description: Not found
+  /orders:
+    post:
+      responses:
+        '201':
+          description: Created
+          headers:
+            Location:
+              schema:
+                type: string
+  /orders/{orderId}:
+    get:
+      security: []
+      responses:
+        '200':
+          description: OK
+  /health:
+    get:
+      security:
+        - {}
+        - apiKey: []
+      responses:
+        '200':
+          description: OK
+components:
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      in: header
+      name: X-API-Key
+`
+
+func TestHTTPSemanticsRules(t *testing.T) {
+	results := evaluateRules(loadSpecString(t, httpSemanticsSpec), rules.HTTPSemanticsRules()...)
+
+	assert.Equal(t, []string{"GET /users defines a request body"}, findingDetails(results["http-no-request-body"]))
+	assert.Equal(t, "$.paths./users.get.requestBody", results["http-no-request-body"].Findings[0].Location.Path)
+
+	// /orders answers 201 with a Location header
+	assert.Equal(t, []string{"POST /users creates a resource but has no 201 response"}, findingDetails(results["http-post-created"]))
+
+	assert.Equal(t, []string{"DELETE /users/{userId} 204 response defines content"}, findingDetails(results["http-no-content-body"]))
+	assert.Equal(t, "$.paths./users/{userId}.delete.responses.204", results["http-no-content-body"].Findings[0].Location.Path)
+
+	// 4XX and default cover 401 and 403, security: [] and {} allow anonymous access
+	assert.Equal(t, []string{
+		"POST /orders is secured but has no 401 or 403 response",
+		"POST /users is secured but has no 403 response",
+		"PUT /users/{userId} is secured but has no 401 or 403 response",
+	}, findingDetails(results["http-auth-responses"]))
+
+	assert.Equal(t, []string{
+		"GET /orders/{orderId} has path parameters but no 404 response",
+		"PUT /users/{userId} has path parameters but no 404 response",
+	}, findingDetails(results["http-not-found-response"]))
+
+	// The 4XX range of GET /users covers 429
+	assert.True(t, results["http-rate-limit-response"].Passed)
+
+	for _, id := range []string{"http-no-request-body", "http-post-created", "http-no-content-body", "http-auth-responses", "http-not-found-response"} {
+		assert.False(t, results[id].Passed, id)
+		assert.Equal(t, "http_semantics", results[id].Category, id)
+	}
+}
+
+func TestErrorResponseRuleRanges(t *testing.T) {
+	result := (&rules.ErrorResponseRule{}).Evaluate(loadSpecString(t, httpSemanticsSpec))
+
+	// GET /users/{userId} has a default response and GET /users a 4XX range
+	assert.Equal(t, []string{
+		"GET /health has no 400 or 500 response",
+		"POST /orders has no 400 or 500 response",
+		"GET /orders/{orderId} has no 400 or 500 response",
+		"GET /users has no 500 response",
+		"POST /users has no 400 or 500 response",
+		"PUT /users/{userId} has no 400 or 500 response",
+		"DELETE /users/{userId} has no 400 or 500 response",
+	}, findingDetails(result))
+}
+
+func TestRateLimitResponseRule(t *testing.T) {
+	source := `openapi: 3.0.3
+info:
+  title: Search
+  version: 1.0.0
+paths:
+  /search:
+    get:
+      responses:
+        '200':
+          description: OK
+          headers:
+            RateLimit-Limit:
+              schema:
+                type: integer
+`
+	result := (&rules.RateLimitResponseRule{}).Evaluate(loadSpecString(t, source))
+	assert.False(t, result.Passed)
+	require.Len(t, result.Findings, 1)
+	assert.Equal(t, "GET /search declares rate-limit headers but no 429 response", result.Findings[0].Detail)
+	assert.Equal(t, "RateLimit-Limit", result.Findings[0].Metadata["headers"])
+}
+
+func TestCreatedResponseRuleRange(t *testing.T) {
+	source := `openapi: 3.0.3
+info:
+  title: Created
+  version: 1.0.0
+paths:
+  /users:
+    post:
+      responses:
+        2XX:
+          description: Created
+  /users/{userId}:
+    get:
+      parameters:
+        - name: userId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK
+`
+	// The 2XX range covers 201, so the missing header is reported where the range is declared
+	result := (&rules.CreatedResponseRule{}).Evaluate(loadSpecString(t, source))
+	require.Len(t, result.Findings, 1)
+	assert.Equal(t, "POST /users 2XX response has no Location header", result.Findings[0].Detail)
+	assert.Equal(t, "$.paths./users.post.responses.2XX", result.Findings[0].Location.Path)
+}
@@ -0,0 +1,102 @@
+package test
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/copyleftdev/specgrade/reporter"
+)
+
+type junitOutput struct {
+	Tests    int `xml:"tests,attr"`
+	Failures int `xml:"failures,attr"`
+	Errors   int `xml:"errors,attr"`
+	Suites   []struct {
+		Name     string `xml:"name,attr"`
+		Tests    int    `xml:"tests,attr"`
+		Failures int    `xml:"failures,attr"`
+		Errors   int    `xml:"errors,attr"`
+		Cases    []struct {
+			Name    string `xml:"name,attr"`
+			Failure *struct {
+				Message string `xml:"message,attr"`
+				Body    string `xml:",chardata"`
+			} `xml:"failure"`
+			Error *struct {
+				Message string `xml:"message,attr"`
+			} `xml:"error"`
+		} `xml:"testcase"`
+	} `xml:"testsuite"`
+}
+
+func junitResults() []core.RuleResult {
+	return []core.RuleResult{
+		{RuleID: "info-title", Passed: true, Detail: "Title present", Severity: "error"},
+		{
+			RuleID:   "operation-description",
+			Detail:   "Description issues: 1 missing descriptions",
+			Severity: "warning",
+			Findings: []core.Finding{{
+				Detail:   "GET /users has no description",
+				Location: &core.RuleLocation{Path: "$.paths./users.get", FileRef: "openapi.yaml:12:5"},
+			}},
+			Suggestion: &core.ActionableFix{Title: "Add Operation Descriptions", Description: "Describe what each operation does"},
+		},
+		{RuleID: "slow-rule", Detail: "Rule timed out after 30s", Errored: true},
+	}
+}
+
+func TestFormatJUnit(t *testing.T) {
+	rep := reporter.NewReporter()
+	report := rep.GenerateReport(&core.SpecContext{Version: "3.1.0"}, junitResults())
+
+	output, err := rep.FormatJUnit(report, "openapi.yaml")
+	require.NoError(t, err)
+
+	var junit junitOutput
+	require.NoError(t, xml.Unmarshal([]byte(output), &junit))
+	assert.Equal(t, 3, junit.Tests)
+	assert.Equal(t, 1, junit.Failures)
+	assert.Equal(t, 1, junit.Errors)
+
+	require.Len(t, junit.Suites, 1)
+	suite := junit.Suites[0]
+	assert.Equal(t, "openapi.yaml", suite.Name)
+	require.Len(t, suite.Cases, 3)
+
+	// Each rule is a testcase, failures carry the findings and the suggested fix
+	assert.Nil(t, suite.Cases[0].Failure)
+	failure := suite.Cases[1].Failure
+	require.NotNil(t, failure)
+	assert.Equal(t, "Description issues: 1 missing descriptions", failure.Message)
+	assert.Contains(t, failure.Body, "- GET /users has no description (openapi.yaml:12:5)")
+	assert.Contains(t, failure.Body, "Fix: Add Operation Descriptions")
+	require.NotNil(t, suite.Cases[2].Error)
+}
+
+func TestFormatMultiJUnit(t *testing.T) {
+	rep := reporter.NewReporter()
+	multi := rep.GenerateMultiReport([]core.SpecReport{
+		{Target: "billing/openapi.yaml", Report: rep.GenerateReport(&core.SpecContext{Version: "3.1.0"}, junitResults())},
+		{Target: "broken/openapi.yaml", Error: "failed to load OpenAPI spec"},
+	})
+
+	output, err := rep.FormatMultiJUnit(multi)
+	require.NoError(t, err)
+
+	var junit junitOutput
+	require.NoError(t, xml.Unmarshal([]byte(output), &junit))
+
+	// One testsuite per spec, a spec that fails to load is an error
+	require.Len(t, junit.Suites, 2)
+	assert.Equal(t, "billing/openapi.yaml", junit.Suites[0].Name)
+	assert.Equal(t, "broken/openapi.yaml", junit.Suites[1].Name)
+	assert.Equal(t, 1, junit.Suites[1].Errors)
+	assert.Equal(t, "failed to load OpenAPI spec", junit.Suites[1].Cases[0].Error.Message)
+	assert.Equal(t, 4, junit.Tests)
+	assert.Equal(t, 2, junit.Errors)
+}
@@ -0,0 +1,71 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/copyleftdev/specgrade/fetcher"
+)
+
+func TestFileSpecLoader(t *testing.T) {
+	data, err := os.ReadFile("./sample-spec/openapi.yaml")
+	require.NoError(t, err)
+
+	// Spec files do not need one of the well-known names
+	specFile := filepath.Join(t.TempDir(), "billing-api.v2.yaml")
+	require.NoError(t, os.WriteFile(specFile, data, 0644))
+
+	ctx, err := fetcher.NewSpecLoader(specFile).LoadContext("3.1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "Sample API", ctx.Spec.Info.Title)
+
+	position, ok := ctx.Source.Locate("$.info.title")
+	require.True(t, ok)
+	assert.Equal(t, "billing-api.v2.yaml", position.File)
+	assert.Equal(t, 3, position.Line)
+}
+
+func TestReaderSpecLoader(t *testing.T) {
+	data, err := os.ReadFile("./sample-spec/openapi.yaml")
+	require.NoError(t, err)
+
+	loader := fetcher.NewReaderSpecLoader("stdin", strings.NewReader(string(data)))
+	ctx, err := loader.LoadContext("3.1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "Sample API", ctx.Spec.Info.Title)
+
+	position, ok := ctx.Source.Locate("$.info.title")
+	require.True(t, ok)
+	assert.Equal(t, "stdin", position.File)
+
+	// The stream is buffered, so loading again yields the same spec
+	spec, err := loader.Load("3.1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "Sample API", spec.Info.Title)
+
+	_, err = fetcher.NewReaderSpecLoader("stdin", strings.NewReader("")).Load("3.1.0")
+	assert.Error(t, err)
+}
+
+func TestURLSpecLoader(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.Dir("./sample-spec")))
+	defer server.Close()
+
+	ctx, err := fetcher.NewSpecLoader(server.URL + "/openapi.yaml").LoadContext("3.1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "Sample API", ctx.Spec.Info.Title)
+
+	position, ok := ctx.Source.Locate("$.info.title")
+	require.True(t, ok)
+	assert.Equal(t, server.URL+"/openapi.yaml", position.File)
+
+	_, err = fetcher.NewSpecLoader(server.URL + "/missing.yaml").Load("3.1.0")
+	assert.Error(t, err)
+}
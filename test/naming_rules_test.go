@@ -0,0 +1,245 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/copyleftdev/specgrade/registry"
+	"github.com/copyleftdev/specgrade/rules"
+	runnerPkg "github.com/copyleftdev/specgrade/runner"
+	"github.com/copyleftdev/specgrade/utils"
+)
+
+const namingSpec = `openapi: 3.0.3
+info:
+  title: Naming
+  version: 1.0.0
+paths:
+  /user/{userId}:
+    parameters:
+      - name: x-tenant-id
+        in: header
+        schema:
+          type: string
+    get:
+      operationId: getUser
+      responses:
+        '200':
+          description: OK
+          headers:
+            X-Request-Id:
+              schema:
+                type: string
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/UserProfile'
+  /userProfiles/{profileId}/addresses:
+    get:
+      operationId: list_addresses
+      responses:
+        '200':
+          description: OK
+  /categories/{categoryId}/media/{mediaId}:
+    get:
+      operationId: GetMedia
+      responses:
+        '200':
+          description: OK
+  /files:upload:
+    post:
+      operationId: uploadFile
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                file_name:
+                  type: string
+      responses:
+        '200':
+          description: OK
+components:
+  schemas:
+    UserProfile:
+      type: object
+      properties:
+        id:
+          type: string
+        firstName:
+          type: string
+        lastName:
+          type: string
+        _links:
+          type: object
+        address:
+          type: object
+          properties:
+            postalCode:
+              type: string
+            street_name:
+              type: string
+    user_settings:
+      type: object
+      properties:
+        darkMode:
+          type: boolean
+`
+
+func TestNamingRules(t *testing.T) {
+	spec := loadSpecString(t, namingSpec)
+	results := evaluateRules(spec, rules.NamingRules()...)
+
+	// media is uncountable and the custom method of /files:upload is a word of its own
+	assert.Equal(t, []string{
+		"Path /user/{userId} names the collection user in the singular, expected users",
+		"Path /userProfiles/{profileId}/addresses segment userProfiles is not kebab-case, expected user-profiles",
+	}, findingDetails(results["naming-path-segments"]))
+	assert.Equal(t, "/user/{userId}", results["naming-path-segments"].Findings[0].Location.Endpoint)
+	assert.Equal(t, "$.paths./user/{userId}.segments.0", results["naming-path-segments"].Findings[0].Location.Path)
+
+	// Most properties are camelCase; _links follows its own convention
+	property := results["naming-property-case"]
+	assert.Equal(t, []string{
+		"Schema UserProfile property address.street_name is not camelCase, expected streetName",
+		"POST /files:upload request body property file_name is not camelCase, expected fileName",
+	}, findingDetails(property))
+	assert.Equal(t, "$.components.schemas.UserProfile.properties.address.properties.street_name", property.Findings[0].Location.Path)
+	assert.Equal(t, "camelCase", property.Metadata["style"])
+	assert.Equal(t, "true", property.Metadata["detected"])
+
+	assert.Equal(t, []string{
+		"GET /categories/{categoryId}/media/{mediaId} operationId GetMedia is not camelCase, expected getMedia",
+		"GET /userProfiles/{profileId}/addresses operationId list_addresses is not camelCase, expected listAddresses",
+	}, findingDetails(results["naming-operation-id"]))
+	assert.Equal(t, "$.paths./userProfiles/{profileId}/addresses.get.operationId", results["naming-operation-id"].Findings[1].Location.Path)
+
+	assert.Equal(t, []string{"Schema user_settings is not PascalCase, expected UserSettings"}, findingDetails(results["naming-schema-names"]))
+
+	assert.Equal(t, []string{"/user/{userId} header x-tenant-id is not Train-Case, expected X-Tenant-Id"}, findingDetails(results["naming-header-names"]))
+	header := results["naming-header-names"].Findings[0].Location.Path
+	assert.Equal(t, "$.paths./user/{userId}.parameters.0", header)
+	position, ok := spec.Source.Locate(header)
+	require.True(t, ok)
+	assert.Equal(t, 8, position.Line)
+
+	for _, result := range results {
+		assert.False(t, result.Passed, result.RuleID)
+		assert.Equal(t, "consistency", result.Category)
+	}
+}
+
+func TestPathNamingSegments(t *testing.T) {
+	source := `openapi: 3.0.3
+info:
+  title: Segments
+  version: 1.0.0
+paths:
+  /userProfile/{profileId}/orderItem/{itemId}:
+    get:
+      responses:
+        '200':
+          description: OK
+`
+	spec := loadSpecString(t, source)
+	result := (&rules.PathNamingRule{}).Evaluate(spec)
+
+	// Each segment is one finding, with the style and the plural in one suggestion
+	assert.Equal(t, []string{
+		"Path /userProfile/{profileId}/orderItem/{itemId} segment userProfile is not kebab-case and names a collection in the singular, expected user-profiles",
+		"Path /userProfile/{profileId}/orderItem/{itemId} segment orderItem is not kebab-case and names a collection in the singular, expected order-items",
+	}, findingDetails(result))
+	require.Len(t, result.Findings, 2)
+	assert.NotEqual(t, runnerPkg.Fingerprint(result.RuleID, result.Findings[0]), runnerPkg.Fingerprint(result.RuleID, result.Findings[1]))
+
+	rule := &rules.PathNamingRule{}
+	require.NoError(t, rule.Configure(core.RuleOptions{"style": "snake_case"}))
+	assert.Equal(t, []string{
+		"Path /userProfile/{profileId}/orderItem/{itemId} segment userProfile is not snake_case and names a collection in the singular, expected user_profiles",
+		"Path /userProfile/{profileId}/orderItem/{itemId} segment orderItem is not snake_case and names a collection in the singular, expected order_items",
+	}, findingDetails(rule.Evaluate(spec)))
+}
+
+func TestPropertyNamingDetectsMajority(t *testing.T) {
+	spec := loadSpecString(t, `openapi: 3.0.3
+info:
+  title: Snake
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Order:
+      allOf:
+        - type: object
+          properties:
+            id:
+              type: string
+            order_number:
+              type: string
+            created_at:
+              type: string
+            customerName:
+              type: string
+`)
+
+	property := (&rules.PropertyNamingRule{}).Evaluate(spec)
+	assert.Equal(t, "snake_case", property.Metadata["style"])
+	assert.Equal(t, []string{"Schema Order property customerName is not snake_case, expected customer_name"}, findingDetails(property))
+
+	path := property.Findings[0].Location.Path
+	assert.Equal(t, "$.components.schemas.Order.allOf.0.properties.customerName", path)
+	position, ok := spec.Source.Locate(path)
+	require.True(t, ok)
+	assert.Equal(t, 18, position.Line)
+}
+
+func TestNamingRulesConfigure(t *testing.T) {
+	reg := registry.NewRuleRegistry()
+	for _, rule := range rules.NamingRules() {
+		reg.Register(rule)
+	}
+
+	config, err := utils.LoadConfig(writeConfig(t, `
+rules:
+  naming-path-segments:
+    style: snake_case
+    plural_collections: false
+  naming-property-case:
+    style: snake_case
+  naming-operation-id:
+    style: snake_case
+  naming-header-names:
+    style: kebab-case
+`))
+	require.NoError(t, err)
+	require.NoError(t, reg.Configure(config.Rules))
+
+	results := evaluateRules(loadSpecString(t, namingSpec), reg.AllRules()...)
+
+	assert.Equal(t, []string{
+		"Path /userProfiles/{profileId}/addresses segment userProfiles is not snake_case, expected user_profiles",
+	}, findingDetails(results["naming-path-segments"]))
+	assert.Len(t, results["naming-property-case"].Findings, 4)
+	assert.NotContains(t, results["naming-property-case"].Metadata, "detected")
+	assert.Equal(t, []string{
+		"GET /categories/{categoryId}/media/{mediaId} operationId GetMedia is not snake_case, expected get_media",
+		"POST /files:upload operationId uploadFile is not snake_case, expected upload_file",
+		"GET /user/{userId} operationId getUser is not snake_case, expected get_user",
+	}, findingDetails(results["naming-operation-id"]))
+	assert.Equal(t, []string{
+		"GET /user/{userId} response 200 header X-Request-Id is not kebab-case, expected x-request-id",
+	}, findingDetails(results["naming-header-names"]))
+
+	for id, options := range map[string]core.RuleOptions{
+		"naming-schema-names":  {"style": "Title Case"},
+		"naming-path-segments": {"plural_collections": "yes"},
+		"naming-header-names":  {"style": "auto"},
+	} {
+		err := reg.Configure(map[string]core.RuleSettings{id: {Options: options}})
+		assert.Error(t, err, id)
+	}
+}
@@ -0,0 +1,115 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/copyleftdev/specgrade/reporter"
+	"github.com/copyleftdev/specgrade/utils"
+)
+
+// sampleResults mirrors the sample spec: two warnings failed, errors passed
+func sampleResults() []core.RuleResult {
+	return []core.RuleResult{
+		{RuleID: "info-title", Passed: true},
+		{RuleID: "info-version", Passed: true},
+		{RuleID: "paths-exist", Passed: true},
+		{RuleID: "operation-operationId-unique", Passed: true},
+		{RuleID: "oas3-valid-schema-example", Passed: true},
+		{RuleID: "operation-description", Passed: true},
+		{RuleID: "operation-success-response", Passed: false, Category: "error_handling"},
+		{RuleID: "oas3-security-defined", Passed: false, Category: "security"},
+	}
+}
+
+func TestBuiltinProfiles(t *testing.T) {
+	tests := []struct {
+		profile       string
+		expectedGrade string
+		expectedScore int
+		expectedCap   string
+	}{
+		{"", "B", 75, ""},
+		{"default", "B", 75, ""},
+		{"strict", "C-", 66, "C"},
+		{"lenient", "A-", 84, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run("profile_"+tt.profile, func(t *testing.T) {
+			profile, err := reporter.ResolveProfile(tt.profile, nil)
+			require.NoError(t, err)
+
+			grader := reporter.NewProfileGrader(profile)
+			results := sampleResults()
+
+			assert.Equal(t, tt.expectedGrade, grader.Grade(results))
+			assert.Equal(t, tt.expectedScore, grader.CalculateScore(results))
+			assert.Equal(t, tt.expectedCap, grader.Breakdown(results).Cap)
+		})
+	}
+
+	_, err := reporter.ResolveProfile("partner", nil)
+	assert.ErrorContains(t, err, "unknown grading profile: partner")
+}
+
+func TestCustomProfileFromConfig(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "specgrade.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`
+profile: partner
+profiles:
+  partner:
+    extends: strict
+    description: Partner-facing APIs
+    thresholds:
+      D: 55
+    rule_weights:
+      operation-success-response: 2
+    category_caps:
+      security: B
+`), 0644))
+
+	config, err := utils.LoadConfig(configFile)
+	require.NoError(t, err)
+	assert.Equal(t, "partner", config.Profile)
+
+	profile, err := reporter.ResolveProfile(config.Profile, config.Profiles)
+	require.NoError(t, err)
+	assert.Equal(t, "partner", profile.Name)
+	assert.Equal(t, "strict", profile.Extends)
+	assert.Equal(t, "Partner-facing APIs", profile.Description)
+
+	// Overrides are applied on top of the strict profile
+	assert.Equal(t, 55.0, profile.Thresholds["D"])
+	assert.Equal(t, 98.0, profile.Thresholds["A+"])
+	assert.Equal(t, 3.0, profile.Scoring.SeverityMultipliers["error"])
+	assert.Equal(t, "B", profile.CategoryCaps["security"])
+	assert.Equal(t, "B", profile.CategoryCaps["schema"])
+
+	// earned 6, penalty 2 × 1.5 + 1.5 = 4.5 → 57%, a D with the lowered threshold
+	grader := reporter.NewProfileGrader(profile)
+	assert.Equal(t, 57, grader.CalculateScore(sampleResults()))
+	assert.Equal(t, "D", grader.Grade(sampleResults()))
+}
+
+func TestCustomProfileValidation(t *testing.T) {
+	_, err := reporter.ResolveProfile("broken", map[string]core.GradingProfile{
+		"broken": {Thresholds: map[string]float64{"E": 40}},
+	})
+	assert.ErrorContains(t, err, "unknown grade in thresholds: E")
+
+	_, err = reporter.ResolveProfile("broken", map[string]core.GradingProfile{
+		"broken": {Thresholds: map[string]float64{"A": 70}},
+	})
+	assert.ErrorContains(t, err, "threshold of A (70) is below the threshold of A- (85)")
+
+	_, err = reporter.ResolveProfile("broken", map[string]core.GradingProfile{
+		"broken": {Extends: "relaxed"},
+	})
+	assert.ErrorContains(t, err, "extends unknown built-in profile: relaxed")
+}
@@ -0,0 +1,173 @@
+package test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/copyleftdev/specgrade/reporter"
+	"github.com/copyleftdev/specgrade/schema"
+)
+
+// TestReportMatchesSchema keeps the report types and the published schema in
+// step: a report using every optional section must validate
+func TestReportMatchesSchema(t *testing.T) {
+	rep := reporter.NewReporter()
+
+	spec := loadSpecFile(t, "sample-spec/openapi.yaml")
+	report := rep.GenerateReport(spec, runLegacySpec(spec))
+	report.Metadata["config_hash"] = "sha256:0"
+	report.Baseline = &core.BaselineSummary{File: "specgrade-baseline.json", Known: 2, New: 1}
+	report.Since = &core.SinceSummary{Ref: "main", Commit: "abc123", Grade: "B", Score: 75, BaseGrade: "C", BaseScore: 62, ScoreDelta: 13, FixedFindings: 2}
+	report.Analytics.QualityTrends = &core.QualityTrends{PreviousScore: 70, ScoreChange: 5, TrendDirection: "improving"}
+
+	// Suppressed and known findings
+	suppressedSpec := loadSpecString(t, suppressedSpec)
+	results := runRules(suppressedSpec, suppressedRules()...)
+	results[1].Known = []core.Finding{{Detail: "Known finding", Known: true, Location: &core.RuleLocation{Path: "$.paths./a.get"}}}
+	suppressedReport := rep.GenerateReport(suppressedSpec, results)
+
+	for _, report := range []*core.Report{report, suppressedReport} {
+		output, err := rep.FormatJSON(report)
+		require.NoError(t, err)
+
+		problems, err := schema.ValidateReport([]byte(output))
+		require.NoError(t, err)
+		assert.Empty(t, problems)
+	}
+
+	assert.Equal(t, core.ReportSchemaVersion, report.SchemaVersion)
+	assert.Contains(t, report.Metadata["spec_hash"], "sha256:")
+	assert.NotEqual(t, report.Metadata["spec_hash"], suppressedReport.Metadata["spec_hash"])
+}
+
+func TestValidateReportErrors(t *testing.T) {
+	report := map[string]interface{}{
+		"schema_version": "0.9",
+		"version":        "3.1.0",
+		"grade":          "B",
+		"score":          120,
+		"rules": []interface{}{
+			map[string]interface{}{"ruleID": "X", "passed": "yes", "detail": "", "severity": "error", "category": "design"},
+		},
+		"metadata": map[string]interface{}{"generated_at": "now", "tool_version": "1.0.0"},
+		"extra":    true,
+	}
+	data, err := json.Marshal(report)
+	require.NoError(t, err)
+
+	problems, err := schema.ValidateReport(data)
+	require.NoError(t, err)
+
+	paths := map[string]bool{}
+	for _, problem := range problems {
+		paths[problem.Path] = true
+	}
+	assert.Equal(t, map[string]bool{
+		"$.schema_version":        true,
+		"$.score":                 true,
+		"$.rules[0].passed":       true,
+		"$.metadata.generated_at": true,
+		"$.extra":                 true,
+	}, paths)
+
+	_, err = schema.ValidateReport([]byte("{"))
+	assert.Error(t, err)
+}
+
+// writeSplitSpec writes a spec whose schema lives in a file of its own, in a
+// directory of its own, and returns the path of the root document
+func writeSplitSpec(t *testing.T, idType string) string {
+	t.Helper()
+	dir := t.TempDir()
+	root := filepath.Join(dir, "openapi.yaml")
+	require.NoError(t, os.WriteFile(root, []byte(`openapi: 3.0.3
+info:
+  title: Split
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: './schemas.yaml#/User'
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "schemas.yaml"), []byte(`User:
+  type: object
+  properties:
+    id:
+      type: `+idType+`
+`), 0644))
+	return root
+}
+
+// TestSpecHashCoversReferencedFiles checks that the spec hash changes with the
+// files external $refs point to, and not with where the spec is checked out
+func TestSpecHashCoversReferencedFiles(t *testing.T) {
+	rep := reporter.NewReporter()
+	specHash := func(path string) string {
+		spec := loadSpecFile(t, path)
+		return rep.GenerateReport(spec, nil).Metadata["spec_hash"]
+	}
+
+	hash := specHash(writeSplitSpec(t, "string"))
+	assert.Contains(t, hash, "sha256:")
+	assert.Equal(t, hash, specHash(writeSplitSpec(t, "string")))
+	assert.NotEqual(t, hash, specHash(writeSplitSpec(t, "integer")))
+}
+
+// TestMultiReportMatchesSchema checks that the combined report of a multi-spec
+// run validates, including specs that failed to load
+func TestMultiReportMatchesSchema(t *testing.T) {
+	rep := reporter.NewReporter()
+	spec := loadSpecFile(t, "sample-spec/openapi.yaml")
+
+	multi := rep.GenerateMultiReport([]core.SpecReport{
+		{Target: "openapi.yaml", Threshold: "B", Passed: true, Report: rep.GenerateReport(spec, runLegacySpec(spec))},
+		{Target: "missing.yaml", Threshold: "B", Error: "failed to load OpenAPI spec"},
+	})
+	assert.Equal(t, core.ReportSchemaVersion, multi.SchemaVersion)
+
+	output, err := rep.FormatMultiJSON(multi)
+	require.NoError(t, err)
+	problems, err := schema.ValidateReport([]byte(output))
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	// Problems are reported against the kind of report that was closest
+	multi.Aggregate.Score = -1
+	output, err = rep.FormatMultiJSON(multi)
+	require.NoError(t, err)
+	problems, err = schema.ValidateReport([]byte(output))
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	assert.Equal(t, "$.aggregate.score", problems[0].Path)
+}
+
+func TestReportSchemaVersion(t *testing.T) {
+	type versioned struct {
+		Properties struct {
+			SchemaVersion struct {
+				Const string `json:"const"`
+			} `json:"schema_version"`
+		} `json:"properties"`
+	}
+	var published struct {
+		Defs struct {
+			Report      versioned `json:"report"`
+			MultiReport versioned `json:"multiReport"`
+		} `json:"$defs"`
+	}
+	require.NoError(t, json.Unmarshal(schema.ReportSchema(), &published))
+	assert.Equal(t, core.ReportSchemaVersion, published.Defs.Report.Properties.SchemaVersion.Const)
+	assert.Equal(t, core.ReportSchemaVersion, published.Defs.MultiReport.Properties.SchemaVersion.Const)
+}
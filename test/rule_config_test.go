@@ -0,0 +1,141 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/copyleftdev/specgrade/registry"
+	"github.com/copyleftdev/specgrade/rules"
+	runnerPkg "github.com/copyleftdev/specgrade/runner"
+	"github.com/copyleftdev/specgrade/utils"
+)
+
+// describedSpec has a single operation with a 17 character description
+func describedSpec() *core.SpecContext {
+	return &core.SpecContext{
+		Spec: &openapi3.T{
+			Info: &openapi3.Info{Title: "Test API", Version: "1.0.0"},
+			Paths: openapi3.Paths{
+				"/users": &openapi3.PathItem{
+					Get: &openapi3.Operation{Description: "Lists all users."},
+				},
+			},
+		},
+		Version: "3.1.0",
+	}
+}
+
+func TestRulesConfig(t *testing.T) {
+	config, err := utils.LoadConfig(writeConfig(t, `
+rules:
+  oas3-security-defined: off
+  info-title: warning
+  operation-description:
+    severity: error
+    min_length: 20
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"oas3-security-defined"}, config.DisabledRules())
+	assert.Equal(t, map[string]string{
+		"info-title":            "warning",
+		"operation-description": "error",
+	}, config.SeverityOverrides())
+	assert.Equal(t, core.RuleOptions{"min_length": 20}, config.Rules["operation-description"].Options)
+
+	_, err = utils.LoadConfig(writeConfig(t, "rules:\n  info-title: fatal\n"))
+	assert.ErrorContains(t, err, `unknown rule severity "fatal"`)
+}
+
+func TestRegistryConfigure(t *testing.T) {
+	newRegistry := func() *registry.RuleRegistry {
+		reg := registry.NewRuleRegistry()
+		reg.Register(&rules.InfoTitleRule{})
+		reg.Register(&rules.OperationDescriptionRule{})
+		return reg
+	}
+
+	tests := []struct {
+		name     string
+		settings map[string]core.RuleSettings
+		err      string
+	}{
+		{
+			name:     "severity only",
+			settings: map[string]core.RuleSettings{"info-title": {Severity: "warning"}},
+		},
+		{
+			name:     "unknown rule",
+			settings: map[string]core.RuleSettings{"no-such-rule": {Severity: "off"}},
+			err:      "unknown rule in config: no-such-rule",
+		},
+		{
+			name:     "rule without options",
+			settings: map[string]core.RuleSettings{"info-title": {Options: core.RuleOptions{"min_length": 3}}},
+			err:      "rule info-title does not accept options",
+		},
+		{
+			name:     "unknown option",
+			settings: map[string]core.RuleSettings{"operation-description": {Options: core.RuleOptions{"max_length": 3}}},
+			err:      "invalid options for rule operation-description: unknown options: max_length",
+		},
+		{
+			name:     "option of the wrong type",
+			settings: map[string]core.RuleSettings{"operation-description": {Options: core.RuleOptions{"min_length": "long"}}},
+			err:      "option min_length must be an integer, got long",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newRegistry().Configure(tt.settings)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestOperationDescriptionMinLength(t *testing.T) {
+	rule := &rules.OperationDescriptionRule{}
+
+	// The default minimum length of 10 accepts the description
+	assert.True(t, rule.Evaluate(describedSpec()).Passed)
+
+	require.NoError(t, rule.Configure(core.RuleOptions{"min_length": 20}))
+	result := rule.Evaluate(describedSpec())
+	assert.False(t, result.Passed)
+	assert.Equal(t, "Description issues: 1 too short (< 20 chars)", result.Detail)
+	require.Len(t, result.Findings, 1)
+	assert.Equal(t, "GET /users description is too short (< 20 chars)", result.Findings[0].Detail)
+}
+
+func TestRunnerSeverityOverrides(t *testing.T) {
+	reg := registry.NewRuleRegistry()
+	reg.Register(&rules.OperationDescriptionRule{MinLength: 20})
+
+	ruleRunner := runnerPkg.NewRunner(reg, []string{})
+	ruleRunner.SetSeverityOverrides(map[string]string{"operation-description": "error"})
+
+	results := ruleRunner.Run(describedSpec())
+	require.Len(t, results, 1)
+	assert.Equal(t, "error", results[0].Severity)
+	for _, finding := range results[0].Issues() {
+		assert.Equal(t, "error", finding.Severity)
+	}
+}
+
+// writeConfig writes a config file into a temporary directory
+func writeConfig(t *testing.T, content string) string {
+	configFile := filepath.Join(t.TempDir(), "specgrade.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(content), 0644))
+	return configFile
+}
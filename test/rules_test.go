@@ -212,3 +212,44 @@ func TestRuleAppliesTo(t *testing.T) {
 		}
 	}
 }
+
+func TestOperationIDRuleFindings(t *testing.T) {
+	rule := &rules.OperationIDRule{}
+
+	spec := &openapi3.T{
+		Paths: openapi3.Paths{
+			"/users": &openapi3.PathItem{
+				Get:  &openapi3.Operation{OperationID: "listUsers"},
+				Post: &openapi3.Operation{},
+			},
+			"/people": &openapi3.PathItem{
+				Get: &openapi3.Operation{OperationID: "listUsers"},
+			},
+		},
+	}
+
+	result := rule.Evaluate(&core.SpecContext{Spec: spec, Version: "3.1.0"})
+
+	if result.Passed {
+		t.Fatalf("Expected rule to fail")
+	}
+
+	if result.Detail != "1 operations missing operation ID, 1 duplicate operation IDs" {
+		t.Errorf("Unexpected rollup detail: %q", result.Detail)
+	}
+
+	issues := result.Issues()
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 findings, got %d", len(issues))
+	}
+
+	expectedPaths := []string{"$.paths./users.get", "$.paths./users.post"}
+	for i, issue := range issues {
+		if issue.Location == nil || issue.Location.Path != expectedPaths[i] {
+			t.Errorf("Finding %d: expected location %q, got %+v", i, expectedPaths[i], issue.Location)
+		}
+		if issue.Severity != result.Severity {
+			t.Errorf("Finding %d: expected severity %q to default to rule severity, got %q", i, result.Severity, issue.Severity)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/copyleftdev/specgrade/registry"
+	"github.com/copyleftdev/specgrade/rules"
+	runnerPkg "github.com/copyleftdev/specgrade/runner"
+)
+
+// panickingRule dereferences a nil pointer while evaluating
+type panickingRule struct{}
+
+func (r *panickingRule) ID() string                    { return "panicking-rule" }
+func (r *panickingRule) Description() string           { return "Always panics" }
+func (r *panickingRule) AppliesTo(version string) bool { return true }
+func (r *panickingRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
+	var responses map[string]string
+	responses["400"] = "Bad Request"
+	return core.RuleResult{RuleID: r.ID(), Passed: true}
+}
+
+// slowRule takes longer than the rule timeout used in the tests
+type slowRule struct{}
+
+func (r *slowRule) ID() string                    { return "slow-rule" }
+func (r *slowRule) Description() string           { return "Never finishes in time" }
+func (r *slowRule) AppliesTo(version string) bool { return true }
+func (r *slowRule) Evaluate(ctx *core.SpecContext) core.RuleResult {
+	time.Sleep(time.Second)
+	return core.RuleResult{RuleID: r.ID(), Passed: true}
+}
+
+func TestRunnerIsolatesFailingRules(t *testing.T) {
+	reg := registry.NewRuleRegistry()
+	reg.Register(&rules.InfoTitleRule{})
+	reg.Register(&panickingRule{})
+	reg.Register(&slowRule{})
+	reg.Register(&rules.InfoVersionRule{})
+
+	ruleRunner := runnerPkg.NewRunner(reg, []string{})
+	ruleRunner.SetRuleTimeout(50 * time.Millisecond)
+
+	results := ruleRunner.Run(loadSpecString(t, minimalSpec))
+	require.Len(t, results, 4)
+
+	// Results keep the registration order
+	assert.Equal(t, "info-title", results[0].RuleID)
+	assert.True(t, results[0].Passed)
+	assert.Equal(t, "info-version", results[3].RuleID)
+	assert.True(t, results[3].Passed)
+
+	panicked := results[1]
+	assert.Equal(t, "panicking-rule", panicked.RuleID)
+	assert.False(t, panicked.Passed)
+	assert.True(t, panicked.Errored)
+	assert.Contains(t, panicked.Detail, "rule panicked")
+	assert.Contains(t, panicked.Metadata["stack"], "panickingRule")
+
+	timedOut := results[2]
+	assert.Equal(t, "slow-rule", timedOut.RuleID)
+	assert.True(t, timedOut.Errored)
+	assert.Equal(t, "rule timed out after 50ms", timedOut.Detail)
+}
+
+func TestRunnerHonorsContext(t *testing.T) {
+	reg := registry.NewRuleRegistry()
+	reg.Register(&rules.InfoTitleRule{})
+	reg.Register(&rules.InfoVersionRule{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := runnerPkg.NewRunner(reg, []string{}).RunContext(ctx, loadSpecString(t, minimalSpec))
+	require.Len(t, results, 2)
+	for _, result := range results {
+		assert.True(t, result.Errored)
+		assert.False(t, result.Passed)
+		assert.Contains(t, result.Detail, "context canceled")
+	}
+}
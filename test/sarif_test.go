@@ -0,0 +1,101 @@
+package test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/copyleftdev/specgrade/reporter"
+	"github.com/copyleftdev/specgrade/rules"
+)
+
+type sarifOutput struct {
+	Version string `json:"version"`
+	Runs    []struct {
+		Tool struct {
+			Driver struct {
+				Rules []struct {
+					ID                   string `json:"id"`
+					HelpURI              string `json:"helpUri"`
+					DefaultConfiguration struct {
+						Level string `json:"level"`
+					} `json:"defaultConfiguration"`
+				} `json:"rules"`
+			} `json:"driver"`
+		} `json:"tool"`
+		Results []struct {
+			RuleID    string `json:"ruleId"`
+			RuleIndex int    `json:"ruleIndex"`
+			Level     string `json:"level"`
+			Message   struct {
+				Text string `json:"text"`
+			} `json:"message"`
+			Locations []struct {
+				PhysicalLocation struct {
+					ArtifactLocation struct {
+						URI string `json:"uri"`
+					} `json:"artifactLocation"`
+					Region struct {
+						StartLine int `json:"startLine"`
+					} `json:"region"`
+				} `json:"physicalLocation"`
+				LogicalLocations []struct {
+					FullyQualifiedName string `json:"fullyQualifiedName"`
+				} `json:"logicalLocations"`
+			} `json:"locations"`
+			PartialFingerprints map[string]string `json:"partialFingerprints"`
+			Suppressions        []struct {
+				Kind          string `json:"kind"`
+				Justification string `json:"justification"`
+			} `json:"suppressions"`
+		} `json:"results"`
+	} `json:"runs"`
+}
+
+func TestFormatSARIF(t *testing.T) {
+	spec := loadSpecString(t, suppressedSpec)
+	results := runRules(spec, suppressedRules()...)
+	rep := reporter.NewReporter()
+	report := rep.GenerateReport(spec, results)
+
+	registered := []core.Rule{&rules.InfoTitleRule{}, &rules.ErrorResponseRule{}, &rules.SchemaExampleConsistencyRule{}}
+	output, err := rep.FormatSARIF(report, "api/openapi.yaml", registered)
+	require.NoError(t, err)
+
+	var sarif sarifOutput
+	require.NoError(t, json.Unmarshal([]byte(output), &sarif))
+	assert.Equal(t, "2.1.0", sarif.Version)
+	require.Len(t, sarif.Runs, 1)
+	run := sarif.Runs[0]
+
+	// Every registered rule is described, including rules without results
+	require.Len(t, run.Tool.Driver.Rules, 3)
+	assert.Equal(t, "info-title", run.Tool.Driver.Rules[0].ID)
+	assert.Equal(t, "operation-success-response", run.Tool.Driver.Rules[1].ID)
+	assert.NotEmpty(t, run.Tool.Driver.Rules[1].HelpURI)
+
+	// Two open findings and four suppressed ones
+	require.Len(t, run.Results, 6)
+	first := run.Results[0]
+	assert.Equal(t, "operation-success-response", first.RuleID)
+	assert.Equal(t, 1, first.RuleIndex)
+	assert.Equal(t, "GET /orders has no 400 or 500 response", first.Message.Text)
+	assert.Equal(t, "warning", first.Level)
+	assert.Len(t, first.PartialFingerprints["specgrade/v1"], 32)
+	require.Len(t, first.Locations, 1)
+	assert.Equal(t, "api/openapi.yaml", first.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.NotZero(t, first.Locations[0].PhysicalLocation.Region.StartLine)
+	assert.Equal(t, "$.paths./orders.get", first.Locations[0].LogicalLocations[0].FullyQualifiedName)
+
+	suppressed := 0
+	for _, result := range run.Results {
+		for _, suppression := range result.Suppressions {
+			assert.Equal(t, "inSource", suppression.Kind)
+			suppressed++
+		}
+	}
+	assert.Equal(t, 4, suppressed)
+}
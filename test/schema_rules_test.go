@@ -0,0 +1,136 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/copyleftdev/specgrade/rules"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestCircularSchemaReferences(t *testing.T) {
+	spec := loadSpecString(t, schemasSpec+`    Agent:
+      type: object
+      required: [id, workspace]
+      properties:
+        id:
+          type: string
+        workspace:
+          $ref: '#/components/schemas/Workspace'
+    Workspace:
+      type: object
+      required: [owner]
+      properties:
+        owner:
+          $ref: '#/components/schemas/Agent'
+        agents:
+          type: array
+          items:
+            $ref: '#/components/schemas/Agent'
+    Node:
+      type: object
+      properties:
+        children:
+          type: array
+          items:
+            $ref: '#/components/schemas/Node'
+    Person:
+      type: object
+      required: [manager]
+      properties:
+        manager:
+          allOf:
+            - $ref: '#/components/schemas/Person'
+          nullable: true
+`)
+
+	result := (&rules.CircularSchemaReferenceRule{}).Evaluate(spec)
+	assert.False(t, result.Passed)
+	assert.Equal(t, "error", result.Severity)
+	assert.Equal(t, "Found 1 schema cycles that cannot be instantiated: Agent → Workspace → Agent", result.Detail)
+	require.Len(t, result.Findings, 3)
+
+	required := result.Findings[0]
+	assert.Equal(t, "error", required.Severity)
+	assert.Equal(t, "$.components.schemas.Agent.properties.workspace", required.Location.Path)
+	assert.Equal(t, "required", required.Metadata["cycle_type"])
+	assert.Equal(t, "#/components/schemas/Agent → #/components/schemas/Workspace → #/components/schemas/Agent", required.Metadata["ref_chain"])
+
+	// Arrays and nullable references let recursion terminate
+	assert.Equal(t, "info", result.Findings[1].Severity)
+	assert.Equal(t, "Node → Node is recursive and can terminate: Node.properties.children.items is optional", result.Findings[1].Detail)
+	assert.Equal(t, "info", result.Findings[2].Severity)
+	assert.Equal(t, "recursive", result.Findings[2].Metadata["cycle_type"])
+
+	// Paths index into allOf with a dot so they resolve; the detail keeps the bracket form
+	person := result.Findings[2]
+	assert.Equal(t, "$.components.schemas.Person.properties.manager.allOf.0", person.Location.Path)
+	assert.Contains(t, person.Detail, "Person.properties.manager.allOf[0]")
+	position, ok := spec.Source.Locate(person.Location.Path)
+	require.True(t, ok)
+	assert.Equal(t, 39, position.Line)
+}
+
+func TestCircularSchemaReferencesRecursiveOnly(t *testing.T) {
+	spec := loadSpecString(t, schemasSpec+`    Category:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        parent:
+          $ref: '#/components/schemas/Category'
+    Tag:
+      type: object
+      properties:
+        name:
+          type: string
+`)
+
+	result := (&rules.CircularSchemaReferenceRule{}).Evaluate(spec)
+	assert.True(t, result.Passed)
+	assert.Equal(t, "1 recursive schema cycles, all can terminate: Category → Category", result.Detail)
+	require.Len(t, result.Findings, 1)
+	assert.Empty(t, result.Issues())
+}
+
+// denseSchemas has count schemas where each one references every later one,
+// and every earlier one too when cyclic. It is built in memory: the loader
+// cannot resolve that many cycles.
+func denseSchemas(count int, cyclic bool) *core.SpecContext {
+	schemas := openapi3.Schemas{}
+	for i := 0; i < count; i++ {
+		properties := openapi3.Schemas{}
+		for j := 0; j < count; j++ {
+			if j > i || cyclic && j != i {
+				properties[fmt.Sprintf("s%02d", j)] = &openapi3.SchemaRef{Ref: fmt.Sprintf("#/components/schemas/S%02d", j)}
+			}
+		}
+		schemas[fmt.Sprintf("S%02d", i)] = &openapi3.SchemaRef{Value: &openapi3.Schema{Type: "object", Properties: properties}}
+	}
+	return &core.SpecContext{
+		Spec:    &openapi3.T{Components: &openapi3.Components{Schemas: schemas}},
+		Version: "3.1.0",
+	}
+}
+
+// TestCircularSchemaReferencesLargeGraphs guards against a search that grows
+// with the number of paths between schemas instead of the number of cycles
+func TestCircularSchemaReferencesLargeGraphs(t *testing.T) {
+	started := time.Now()
+	result := (&rules.CircularSchemaReferenceRule{}).Evaluate(denseSchemas(40, false))
+	assert.Less(t, time.Since(started), 2*time.Second)
+	assert.True(t, result.Passed)
+	assert.Empty(t, result.Findings)
+
+	started = time.Now()
+	result = (&rules.CircularSchemaReferenceRule{}).Evaluate(denseSchemas(30, true))
+	assert.Less(t, time.Since(started), 2*time.Second)
+	assert.Len(t, result.Findings, 50)
+	assert.Equal(t, "only the first 50 cycles are reported", result.Metadata["truncated"])
+}
@@ -0,0 +1,118 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/copyleftdev/specgrade/rules"
+)
+
+const securitySpec = `openapi: 3.0.3
+info:
+  title: Security
+  version: 1.0.0
+servers:
+  - url: https://api.example.com
+  - url: http://api.example.com
+  - url: http://localhost:8080
+paths:
+  /users:
+    get:
+      security:
+        - oauth2: [users:read, users:admin]
+        - bearer: []
+      responses:
+        '200':
+          description: OK
+    post:
+      responses:
+        '201':
+          description: Created
+  /health:
+    get:
+      security: []
+      responses:
+        '200':
+          description: OK
+components:
+  securitySchemes:
+    oauth2:
+      type: oauth2
+      flows:
+        clientCredentials:
+          tokenUrl: https://example.com/oauth/token
+          scopes:
+            users:read: Read users
+    queryKey:
+      type: apiKey
+      in: query
+      name: api_key
+    basic:
+      type: http
+      scheme: basic
+`
+
+func TestSecurityRules(t *testing.T) {
+	spec := loadSpecString(t, securitySpec)
+	results := evaluateRules(spec, rules.SecurityRules()...)
+
+	// GET /health is explicitly public
+	assert.Equal(t, []string{"POST /users has no security requirement"}, findingDetails(results["security-operation-coverage"]))
+
+	assert.Equal(t, []string{"GET /users references undefined security scheme bearer"}, findingDetails(results["security-scheme-defined"]))
+	assert.Equal(t, "$.paths./users.get.security.1", results["security-scheme-defined"].Findings[0].Location.Path)
+
+	assert.Equal(t, []string{"GET /users requires scope users:admin of oauth2, which no flow declares"}, findingDetails(results["security-oauth2-scopes"]))
+
+	assert.Equal(t, []string{"API key scheme queryKey is passed in the query parameter api_key"}, findingDetails(results["security-no-apikey-in-query"]))
+
+	// localhost is a development server
+	assert.Equal(t, []string{"Server http://api.example.com does not use TLS but basic authentication (basic) is defined"}, findingDetails(results["security-basic-auth-tls"]))
+	assert.Equal(t, "$.servers.1.url", results["security-basic-auth-tls"].Findings[0].Location.Path)
+
+	// Findings resolve to the line of the requirement or server they are about
+	for id, line := range map[string]int{"security-scheme-defined": 14, "security-basic-auth-tls": 7} {
+		position, ok := spec.Source.Locate(results[id].Findings[0].Location.Path)
+		assert.True(t, ok, id)
+		assert.Equal(t, line, position.Line, id)
+	}
+
+	assert.Equal(t, []string{
+		"Security scheme basic is not used by any security requirement",
+		"Security scheme queryKey is not used by any security requirement",
+	}, findingDetails(results["security-unused-scheme"]))
+	assert.Equal(t, "info", results["security-unused-scheme"].Severity)
+
+	for id, result := range results {
+		assert.False(t, result.Passed, id)
+		assert.Equal(t, "security", result.Category, id)
+	}
+}
+
+func TestSecurityRulesGlobalSecurity(t *testing.T) {
+	spec := loadSpecString(t, `openapi: 3.0.3
+info:
+  title: Security
+  version: 1.0.0
+servers:
+  - url: https://api.example.com
+security:
+  - bearer: []
+paths:
+  /users:
+    get:
+      responses:
+        '200':
+          description: OK
+components:
+  securitySchemes:
+    bearer:
+      type: http
+      scheme: bearer
+`)
+
+	for id, result := range evaluateRules(spec, rules.SecurityRules()...) {
+		assert.True(t, result.Passed, "%s: %s", id, result.Detail)
+	}
+}
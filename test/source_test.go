@@ -0,0 +1,98 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/copyleftdev/specgrade/fetcher"
+	"github.com/copyleftdev/specgrade/registry"
+	"github.com/copyleftdev/specgrade/rules"
+	runnerPkg "github.com/copyleftdev/specgrade/runner"
+	"github.com/copyleftdev/specgrade/versions"
+)
+
+func TestSourceIndexLocate(t *testing.T) {
+	loader := fetcher.NewLocalSpecLoader("./sample-spec/bad-example")
+	ctx, err := loader.LoadContext("3.1.0")
+	require.NoError(t, err)
+	require.NotNil(t, ctx.Source)
+
+	tests := []struct {
+		name     string
+		path     string
+		expected core.SourcePosition
+	}{
+		{
+			name:     "operation in root file",
+			path:     "$.paths./v1/clinics.post",
+			expected: core.SourcePosition{File: "api.yaml", Line: 394, Column: 5},
+		},
+		{
+			name:     "schema property behind external ref",
+			path:     "$.paths./v1/clinics.post.requestBody.content.application/json.schema.properties.name",
+			expected: core.SourcePosition{File: "clinics.yaml", Line: 15, Column: 9},
+		},
+		{
+			name:     "missing node resolves to deepest existing ancestor",
+			path:     "$.paths./v1/clinics.post.description",
+			expected: core.SourcePosition{File: "api.yaml", Line: 394, Column: 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			position, ok := ctx.Source.Locate(tt.path)
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, position)
+		})
+	}
+}
+
+func TestRunnerResolvesFindingLocations(t *testing.T) {
+	loader := fetcher.NewLocalSpecLoader("./sample-spec/bad-example")
+	ctx, err := loader.LoadContext("3.1.0")
+	require.NoError(t, err)
+
+	reg := registry.NewRuleRegistry()
+	reg.Register(&rules.OperationDescriptionRule{})
+
+	results := runnerPkg.NewRunner(reg, []string{}).Run(ctx)
+	require.Len(t, results, 1)
+	require.NotEmpty(t, results[0].Findings)
+
+	for _, finding := range results[0].Findings {
+		require.NotNil(t, finding.Location)
+		assert.Equal(t, "api.yaml", finding.Location.File)
+		assert.Greater(t, finding.Location.Line, 0, "finding %q should have a line", finding.Detail)
+		assert.NotEmpty(t, finding.Location.FileRef)
+	}
+}
+
+func TestSwaggerConversion(t *testing.T) {
+	loader := fetcher.NewLocalSpecLoader("./sample-spec/swagger-example")
+	ctx, err := loader.LoadContext("3.1.0")
+	require.NoError(t, err)
+
+	assert.Equal(t, fetcher.SwaggerVersion, ctx.SourceVersion)
+	assert.Equal(t, fetcher.ConvertedVersion, ctx.Version)
+
+	// 2.0 is a supported version, graded as the version Swagger specs are converted to
+	assert.True(t, versions.IsValidVersion(fetcher.SwaggerVersion))
+	assert.Equal(t, fetcher.ConvertedVersion, versions.GradingVersion(fetcher.SwaggerVersion))
+	assert.Equal(t, "3.1.0", versions.GradingVersion("3.1.0"))
+	require.NotNil(t, ctx.Spec.Paths["/pets"])
+	require.NotNil(t, ctx.Spec.Paths["/pets"].Post.RequestBody, "body parameter should become a request body")
+	require.Contains(t, ctx.Spec.Components.Schemas, "Pet")
+
+	// Converted sections resolve to their Swagger 2.0 counterparts in the original file
+	position, ok := ctx.Source.Locate("$.components.schemas.Pet.properties.id.example")
+	require.True(t, ok)
+	assert.Equal(t, core.SourcePosition{File: "swagger.yaml", Line: 64, Column: 9}, position)
+
+	position, ok = ctx.Source.Locate("$.paths./pets.get.responses.200.content.application/json.schema")
+	require.True(t, ok)
+	assert.Equal(t, core.SourcePosition{File: "swagger.yaml", Line: 34, Column: 11}, position)
+}
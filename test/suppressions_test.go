@@ -0,0 +1,124 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/copyleftdev/specgrade/core"
+	"github.com/copyleftdev/specgrade/reporter"
+	"github.com/copyleftdev/specgrade/rules"
+)
+
+// suppressedRules are the rules whose findings suppressedSpec suppresses
+func suppressedRules() []core.Rule {
+	return []core.Rule{&rules.ErrorResponseRule{}, &rules.SchemaExampleConsistencyRule{}}
+}
+
+const suppressedSpec = `openapi: 3.1.0
+info:
+  title: Suppression API
+  version: 1.0.0
+paths:
+  /health:
+    get:
+      operationId: health
+      x-specgrade-ignore: [operation-success-response]
+      x-specgrade-ignore-reason: Health checks take no input and never return 400
+      responses:
+        "200":
+          description: OK
+  /status:
+    get:
+      operationId: status
+      x-specgrade-ignore: operation-success-response
+      responses:
+        "200":
+          description: OK
+  /users:
+    x-specgrade-ignore:
+      - rule: operation-success-response
+        reason: Legacy endpoint, removed in v2
+    get:
+      operationId: listUsers
+      responses:
+        "200":
+          description: OK
+    post:
+      operationId: createUser
+      responses:
+        "201":
+          description: Created
+  /orders:
+    get:
+      operationId: listOrders
+      responses:
+        "200":
+          description: OK
+components:
+  schemas:
+    Legacy:
+      type: integer
+      example: not-a-number
+      x-specgrade-ignore:
+        oas3-valid-schema-example: Kept for compatibility with v1 clients
+`
+
+func TestSuppressions(t *testing.T) {
+	results := runRules(loadSpecString(t, suppressedSpec), suppressedRules()...)
+	require.Len(t, results, 2)
+
+	// Only /orders is unsuppressed, /status is suppressed without a reason
+	responses := results[0]
+	assert.False(t, responses.Passed)
+	require.Len(t, responses.Findings, 2)
+	assert.Equal(t, "GET /orders has no 400 or 500 response", responses.Findings[0].Detail)
+	assert.Equal(t, "GET /status has no 400 or 500 response", responses.Findings[1].Detail)
+	assert.Contains(t, responses.Findings[1].Metadata["suppression_error"], "has no reason")
+	assert.True(t, strings.HasSuffix(responses.Detail, "(3 suppressed)"), responses.Detail)
+
+	require.Len(t, responses.Suppressed, 3)
+	reasons := map[string]string{}
+	for _, suppression := range responses.Suppressed {
+		reasons[suppression.Detail] = suppression.Reason
+	}
+	assert.Equal(t, map[string]string{
+		"GET /health has no 400 or 500 response": "Health checks take no input and never return 400",
+		"GET /users has no 400 or 500 response":  "Legacy endpoint, removed in v2",
+		"POST /users has no 400 or 500 response": "Legacy endpoint, removed in v2",
+	}, reasons)
+
+	// A path item suppression covers every operation on the path
+	for _, suppression := range responses.Suppressed {
+		if suppression.Reason == "Legacy endpoint, removed in v2" {
+			assert.Equal(t, "$.paths./users", suppression.Declared.Path)
+			assert.NotZero(t, suppression.Declared.Line)
+		}
+	}
+
+	// The only schema example finding is suppressed, so the rule passes
+	examples := results[1]
+	assert.True(t, examples.Passed)
+	assert.Equal(t, "All findings suppressed (1)", examples.Detail)
+	require.Len(t, examples.Suppressed, 1)
+	assert.Equal(t, "$.components.schemas.Legacy", examples.Suppressed[0].Declared.Path)
+}
+
+func TestReportListsSuppressions(t *testing.T) {
+	results := runRules(loadSpecString(t, suppressedSpec), suppressedRules()...)
+
+	rep := reporter.NewReporter()
+	report := rep.GenerateReport(&core.SpecContext{Version: "3.1.0"}, results)
+	require.Len(t, report.Suppressions, 4)
+
+	cli := rep.FormatCLI(report, "openapi.yaml")
+	assert.Contains(t, cli, "🔕 Suppressed Findings (4):")
+	assert.Contains(t, cli, "Reason: Kept for compatibility with v1 clients")
+	assert.Contains(t, cli, "Not suppressed: x-specgrade-ignore at $.paths./status.get has no reason")
+
+	markdown := rep.FormatMarkdown(report, "openapi.yaml")
+	assert.Contains(t, markdown, "## Suppressions")
+	assert.Contains(t, markdown, "Health checks take no input and never return 400")
+}
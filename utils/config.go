@@ -1,6 +1,9 @@
 package utils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -59,8 +62,15 @@ func MergeConfigWithFlags(config *core.Config, flags *core.Config) *core.Config
 	if flags.SpecVersion != "" {
 		merged.SpecVersion = flags.SpecVersion
 	}
+	if flags.Input != "" {
+		merged.Input = flags.Input
+	}
 	if flags.InputDir != "" {
 		merged.InputDir = flags.InputDir
+		// An explicit --target-dir overrides an input configured in the file
+		if flags.Input == "" {
+			merged.Input = ""
+		}
 	}
 	if flags.OutputFormat != "" {
 		merged.OutputFormat = flags.OutputFormat
@@ -68,6 +78,15 @@ func MergeConfigWithFlags(config *core.Config, flags *core.Config) *core.Config
 	if flags.FailThreshold != "" {
 		merged.FailThreshold = flags.FailThreshold
 	}
+	if flags.Profile != "" {
+		merged.Profile = flags.Profile
+	}
+	if flags.Baseline != "" {
+		merged.Baseline = flags.Baseline
+	}
+	if flags.HistoryDir != "" {
+		merged.HistoryDir = flags.HistoryDir
+	}
 	if len(flags.SkipRules) > 0 {
 		merged.SkipRules = flags.SkipRules
 	}
@@ -75,8 +94,57 @@ func MergeConfigWithFlags(config *core.Config, flags *core.Config) *core.Config
 	return &merged
 }
 
+// ConfigHash identifies the settings that affect grading. Where the spec is
+// read from and how the report is written are left out, so the same settings
+// hash the same in every run.
+func ConfigHash(config *core.Config) string {
+	settings := *config
+	settings.Input = ""
+	settings.InputDir = ""
+	settings.OutputFormat = ""
+	settings.HistoryDir = ""
+	settings.ConfigPath = ""
+
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
 // fileExists checks if a file exists
 func fileExists(filename string) bool {
 	_, err := os.Stat(filename)
 	return err == nil
 }
+
+// LoadBaseline reads a baseline file written by 'specgrade baseline create'
+func LoadBaseline(path string) (*core.Baseline, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline file: %w", err)
+	}
+
+	var baseline core.Baseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline file %s: %w", path, err)
+	}
+	if baseline.Version != core.BaselineVersion {
+		return nil, fmt.Errorf("unsupported baseline version %d in %s (expected %d)", baseline.Version, path, core.BaselineVersion)
+	}
+
+	return &baseline, nil
+}
+
+// SaveBaseline writes a baseline file
+func SaveBaseline(path string, baseline *core.Baseline) error {
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal baseline: %w", err)
+	}
+	if err := ioutil.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write baseline file: %w", err)
+	}
+	return nil
+}
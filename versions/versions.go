@@ -2,10 +2,17 @@ package versions
 
 // VersionToSchemaURL maps OpenAPI versions to their schema URLs
 var VersionToSchemaURL = map[string]string{
+	"2.0":   "https://swagger.io/v2/schema.json", // graded after conversion to OpenAPI 3
 	"3.0.0": "https://spec.openapis.org/oas/3.0/schema/2019-04-02",
 	"3.1.0": "https://spec.openapis.org/oas/3.1/schema/2022-10-07",
 }
 
+// ConvertedVersions maps the versions whose specs are converted before grading
+// to the OpenAPI version they are graded as
+var ConvertedVersions = map[string]string{
+	"2.0": "3.0.0",
+}
+
 // IsValidVersion checks if the given version is supported
 func IsValidVersion(version string) bool {
 	_, exists := VersionToSchemaURL[version]
@@ -17,3 +24,12 @@ func GetSchemaURL(version string) (string, bool) {
 	url, exists := VersionToSchemaURL[version]
 	return url, exists
 }
+
+// GradingVersion returns the version rules are evaluated against for a
+// supported version: converted versions are graded as their conversion target
+func GradingVersion(version string) string {
+	if converted, ok := ConvertedVersions[version]; ok {
+		return converted
+	}
+	return version
+}